@@ -0,0 +1,224 @@
+package mapbox
+
+import (
+	"strings"
+)
+
+// PostalAddress is a normalized address built from a geocoding feature and its context.
+type PostalAddress struct {
+	StreetNumber string
+	Street       string
+	Unit         string // e.g. "Suite 280"
+	Neighborhood string // a colloquial area within the city, not used for mail delivery
+	Locality     string // a named area within the city, e.g. "Shoreditch"
+	City         string
+	District     string // an area between the city and the region, e.g. a county
+	Region       string
+	RegionCode   string // e.g. "CA" for California
+	Postcode     string
+	Country      string
+	CountryCode  string // ISO 3166-1 alpha-2, upper case
+}
+
+// PostalAddress converts the feature into a normalized address. Fields the feature does
+// not have context for are left empty.
+func (f *Feature) PostalAddress() PostalAddress {
+	if f == nil || f.Properties == nil {
+		return PostalAddress{}
+	}
+	return newPostalAddress(f.Properties.FeatureType, f.Properties.Name, f.Properties.Context)
+}
+
+func newPostalAddress(featureType Type, name string, context FeatureContext) PostalAddress {
+	var a PostalAddress
+
+	if address := context.Address(); address != nil {
+		a.StreetNumber = address.AddressNumber
+		a.Street = address.StreetName
+	}
+	if street := context.Street(); street != nil && a.Street == "" {
+		a.Street = street.Name
+	}
+	if secondary, ok := context.get(TypeSecondaryAddress); ok {
+		a.Unit = secondary.Name
+	}
+
+	// the feature itself is not part of its own context
+	//nolint:exhaustive
+	switch featureType {
+	case TypeStreet:
+		a.Street = name
+	case TypeSecondaryAddress:
+		a.Unit = name
+	case TypePostcode:
+		a.Postcode = name
+	case TypeNeighborhood:
+		a.Neighborhood = name
+	case TypeLocality:
+		a.Locality = name
+	case TypePlace:
+		a.City = name
+	case TypeDistrict:
+		a.District = name
+	case TypeRegion:
+		a.Region = name
+	case TypeCountry:
+		a.Country = name
+	}
+
+	if neighborhood := context.Neighborhood(); neighborhood != nil {
+		a.Neighborhood = neighborhood.Name
+	}
+	if locality := context.Locality(); locality != nil {
+		a.Locality = locality.Name
+	}
+	if place := context.Place(); place != nil {
+		a.City = place.Name
+	}
+	if a.City == "" {
+		// fall back to the next best thing, leaving the locality to stand in for the city
+		a.City, a.Locality = a.Locality, ""
+	}
+
+	if district := context.District(); district != nil {
+		a.District = district.Name
+	}
+	if region := context.Region(); region != nil {
+		a.Region = region.Name
+		a.RegionCode = region.RegionCode
+		if a.RegionCode == "" {
+			if i := strings.LastIndex(region.RegionCodeFull, "-"); i >= 0 {
+				a.RegionCode = region.RegionCodeFull[i+1:]
+			}
+		}
+	}
+	if postcode := context.Postcode(); postcode != nil {
+		a.Postcode = postcode.Name
+	}
+	if country := context.Country(); country != nil {
+		a.Country = country.Name
+		a.CountryCode = strings.ToUpper(country.CountryCode)
+	}
+
+	return a
+}
+
+//////////////////////////////////////////////////////////////////
+
+// addressStyles maps ISO country codes to the line ordering used by their postal service.
+// Countries not listed use usAddressLines.
+var addressStyles = map[string]func(a PostalAddress) []string{
+	"GB": gbAddressLines,
+	"IE": gbAddressLines,
+
+	"DE": deAddressLines,
+	"AT": deAddressLines,
+	"CH": deAddressLines,
+	"NL": deAddressLines,
+	"BE": deAddressLines,
+	"DK": deAddressLines,
+	"NO": deAddressLines,
+	"SE": deAddressLines,
+	"FI": deAddressLines,
+	"PL": deAddressLines,
+	"CZ": deAddressLines,
+	"IT": deAddressLines,
+	"ES": deAddressLines,
+
+	"JP": jpAddressLines,
+}
+
+// Lines formats the address on multiple lines, ordered the way the address's country
+// expects it. The last line is the country name when known.
+func (a PostalAddress) Lines() []string {
+	style, ok := addressStyles[a.CountryCode]
+	if !ok {
+		style = usAddressLines
+	}
+
+	return nonEmpty(append(style(a), a.Country))
+}
+
+// String formats the address on a single line.
+func (a PostalAddress) String() string {
+	return strings.Join(a.Lines(), ", ")
+}
+
+// 6005 Hidden Valley Road Suite 280
+// Carlsbad, CA 92011
+func usAddressLines(a PostalAddress) []string {
+	region := a.RegionCode
+	if region == "" {
+		region = a.Region
+	}
+
+	city := a.City
+	if region != "" {
+		city = joinNonEmpty(", ", city, joinNonEmpty(" ", region, a.Postcode))
+	} else {
+		city = joinNonEmpty(" ", city, a.Postcode)
+	}
+
+	return []string{
+		joinNonEmpty(" ", a.StreetNumber, a.Street, a.Unit),
+		city,
+	}
+}
+
+// Flat 3
+// 10 Downing Street
+// Westminster
+// London
+// SW1A 2AA
+func gbAddressLines(a PostalAddress) []string {
+	return []string{
+		a.Unit,
+		joinNonEmpty(" ", a.StreetNumber, a.Street),
+		a.Locality,
+		a.City,
+		a.Postcode,
+	}
+}
+
+// Pariser Platz 5
+// Wohnung 3
+// 10117 Berlin
+func deAddressLines(a PostalAddress) []string {
+	return []string{
+		joinNonEmpty(" ", a.Street, a.StreetNumber),
+		a.Unit,
+		joinNonEmpty(" ", a.Postcode, a.City),
+	}
+}
+
+// 〒100-8111
+// Tokyo Chiyoda
+// Chiyoda 1-1
+func jpAddressLines(a PostalAddress) []string {
+	postcode := a.Postcode
+	if postcode != "" {
+		postcode = "〒" + postcode
+	}
+
+	return []string{
+		postcode,
+		joinNonEmpty(" ", a.Region, a.City, a.Locality),
+		joinNonEmpty(" ", a.Street, a.StreetNumber, a.Unit),
+	}
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	return strings.Join(nonEmpty(parts), sep)
+}
+
+func nonEmpty(parts []string) []string {
+	res := make([]string, 0, len(parts))
+
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			res = append(res, part)
+		}
+	}
+
+	return res
+}
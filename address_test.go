@@ -0,0 +1,147 @@
+package mapbox
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFeaturePostalAddress(t *testing.T) {
+	tests := map[string]struct {
+		feature  string
+		expected PostalAddress
+		lines    []string
+	}{
+		"US": {
+			feature: `{"properties":{"feature_type":"secondary_address","name":"Suite 280","context":{
+				"address":{"name":"6005 Hidden Valley Road","address_number":"6005","street_name":"Hidden Valley Road"},
+				"street":{"name":"Hidden Valley Road"},
+				"postcode":{"name":"92011"},
+				"place":{"name":"Carlsbad"},
+				"region":{"name":"California","region_code":"CA","region_code_full":"US-CA"},
+				"country":{"name":"United States","country_code":"us","country_code_alpha_3":"USA"}}}}`,
+			expected: PostalAddress{
+				StreetNumber: "6005", Street: "Hidden Valley Road", Unit: "Suite 280", City: "Carlsbad",
+				Region: "California", RegionCode: "CA", Postcode: "92011", Country: "United States", CountryCode: "US",
+			},
+			lines: []string{"6005 Hidden Valley Road Suite 280", "Carlsbad, CA 92011", "United States"},
+		},
+		"GB": {
+			feature: `{"properties":{"feature_type":"address","name":"10 Downing Street","context":{
+				"address":{"name":"10 Downing Street","address_number":"10","street_name":"Downing Street"},
+				"postcode":{"name":"SW1A 2AA"},
+				"locality":{"name":"Westminster"},
+				"place":{"name":"London"},
+				"region":{"name":"England","region_code":"ENG","region_code_full":"GB-ENG"},
+				"country":{"name":"United Kingdom","country_code":"gb"}}}}`,
+			expected: PostalAddress{
+				StreetNumber: "10", Street: "Downing Street", Locality: "Westminster", City: "London",
+				Region: "England", RegionCode: "ENG", Postcode: "SW1A 2AA", Country: "United Kingdom", CountryCode: "GB",
+			},
+			lines: []string{"10 Downing Street", "Westminster", "London", "SW1A 2AA", "United Kingdom"},
+		},
+		"DE": {
+			feature: `{"properties":{"feature_type":"address","name":"Pariser Platz 5","context":{
+				"address":{"name":"Pariser Platz 5","address_number":"5","street_name":"Pariser Platz"},
+				"postcode":{"name":"10117"},
+				"place":{"name":"Berlin"},
+				"region":{"name":"Berlin","region_code_full":"DE-BE"},
+				"country":{"name":"Germany","country_code":"de"}}}}`,
+			expected: PostalAddress{
+				StreetNumber: "5", Street: "Pariser Platz", City: "Berlin",
+				Region: "Berlin", RegionCode: "BE", Postcode: "10117", Country: "Germany", CountryCode: "DE",
+			},
+			lines: []string{"Pariser Platz 5", "10117 Berlin", "Germany"},
+		},
+		"JP": {
+			feature: `{"properties":{"feature_type":"address","name":"1-1 Chiyoda","context":{
+				"address":{"name":"1-1 Chiyoda","address_number":"1-1","street_name":"Chiyoda"},
+				"postcode":{"name":"100-8111"},
+				"place":{"name":"Chiyoda"},
+				"region":{"name":"Tokyo","region_code":"13","region_code_full":"JP-13"},
+				"country":{"name":"Japan","country_code":"jp"}}}}`,
+			expected: PostalAddress{
+				StreetNumber: "1-1", Street: "Chiyoda", City: "Chiyoda",
+				Region: "Tokyo", RegionCode: "13", Postcode: "100-8111", Country: "Japan", CountryCode: "JP",
+			},
+			lines: []string{"〒100-8111", "Tokyo Chiyoda", "Chiyoda 1-1", "Japan"},
+		},
+		"Place only": {
+			feature: `{"properties":{"feature_type":"place","name":"Victoria Falls","context":{
+				"region":{"name":"Matabeleland North"},
+				"country":{"name":"Zimbabwe","country_code":"zw"}}}}`,
+			expected: PostalAddress{
+				City: "Victoria Falls", Region: "Matabeleland North", Country: "Zimbabwe", CountryCode: "ZW",
+			},
+			lines: []string{"Victoria Falls, Matabeleland North", "Zimbabwe"},
+		},
+		"Neighborhood": {
+			feature: `{"properties":{"feature_type":"neighborhood","name":"Barrio Logan","context":{
+				"place":{"name":"San Diego"},
+				"region":{"name":"California","region_code":"CA"},
+				"country":{"name":"United States","country_code":"us"}}}}`,
+			expected: PostalAddress{
+				Neighborhood: "Barrio Logan", City: "San Diego", Region: "California", RegionCode: "CA",
+				Country: "United States", CountryCode: "US",
+			},
+			lines: []string{"San Diego, CA", "United States"},
+		},
+		"Locality": {
+			feature: `{"properties":{"feature_type":"locality","name":"Shoreditch","context":{
+				"place":{"name":"London"},
+				"country":{"name":"United Kingdom","country_code":"gb"}}}}`,
+			expected: PostalAddress{
+				Locality: "Shoreditch", City: "London", Country: "United Kingdom", CountryCode: "GB",
+			},
+			lines: []string{"Shoreditch", "London", "United Kingdom"},
+		},
+		"District": {
+			feature: `{"properties":{"feature_type":"district","name":"San Diego County","context":{
+				"region":{"name":"California","region_code":"CA"},
+				"country":{"name":"United States","country_code":"us"}}}}`,
+			expected: PostalAddress{
+				District: "San Diego County", Region: "California", RegionCode: "CA",
+				Country: "United States", CountryCode: "US",
+			},
+			lines: []string{"CA", "United States"},
+		},
+		"Region": {
+			feature: `{"properties":{"feature_type":"region","name":"Bavaria","context":{
+				"country":{"name":"Germany","country_code":"de"}}}}`,
+			expected: PostalAddress{Region: "Bavaria", Country: "Germany", CountryCode: "DE"},
+			lines:    []string{"Germany"},
+		},
+		"Country": {
+			feature:  `{"properties":{"feature_type":"country","name":"Japan","context":{}}}`,
+			expected: PostalAddress{Country: "Japan"},
+			lines:    []string{"Japan"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var feature Feature
+			if err := json.Unmarshal([]byte(test.feature), &feature); err != nil {
+				t.Fatal(err)
+			}
+
+			address := feature.PostalAddress()
+			if address != test.expected {
+				t.Errorf("expected address:\n%+v, got:\n%+v", test.expected, address)
+			}
+
+			if lines := address.Lines(); !reflect.DeepEqual(lines, test.lines) {
+				t.Errorf("expected lines %q, got %q", test.lines, lines)
+			}
+		})
+	}
+}
+
+func TestPostalAddressString(t *testing.T) {
+	address := PostalAddress{StreetNumber: "5", Street: "Pariser Platz", City: "Berlin", Postcode: "10117", CountryCode: "DE"}
+
+	expected := "Pariser Platz 5, 10117 Berlin"
+	if address.String() != expected {
+		t.Errorf("expected %q, got %q", expected, address.String())
+	}
+}
@@ -0,0 +1,158 @@
+package mapbox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+const (
+	AutofillSuggestEndpoint  = "/autofill/v1/suggest"
+	AutofillRetrieveEndpoint = "/autofill/v1/retrieve"
+)
+
+type AutofillSuggestRequest struct {
+	// required
+	SearchText   string
+	SessionToken string // see SessionTokenManager
+
+	// optional
+	BBox      BoundingBox
+	Country   string
+	Language  string
+	Limit     int
+	Proximity Coordinate
+	Types     Types // TypeAddress and/or TypeSecondaryAddress
+}
+
+type AutofillSuggestResponse struct {
+	Suggestions []*AutofillSuggestion `json:"suggestions"`
+	Attribution string                `json:"attribution"`
+	URL         string                `json:"url,omitempty"`
+}
+
+type AutofillSuggestion struct {
+	AutofillAddress
+	Description  string         `json:"description,omitempty"`
+	MatchingName string         `json:"matching_name,omitempty"`
+	Action       AutofillAction `json:"action"`
+}
+
+// AutofillAction identifies the suggestion when it is retrieved.
+type AutofillAction struct {
+	ID string `json:"id"`
+}
+
+// AutofillAddress is an address split into the components of an address form.
+// https://docs.mapbox.com/api/search/autofill/#response-suggest-addresses
+type AutofillAddress struct {
+	FullAddress   string `json:"full_address"`
+	FeatureName   string `json:"feature_name,omitempty"`
+	AddressLine1  string `json:"address_line1,omitempty"` // house number and street
+	AddressLine2  string `json:"address_line2,omitempty"` // secondary unit, e.g. "Suite 280"
+	AddressLine3  string `json:"address_line3,omitempty"`
+	AddressLevel1 string `json:"address_level1,omitempty"` // region, e.g. "CA"
+	AddressLevel2 string `json:"address_level2,omitempty"` // city
+	AddressLevel3 string `json:"address_level3,omitempty"` // neighborhood
+	AddressNumber string `json:"address_number,omitempty"`
+	Street        string `json:"street,omitempty"`
+	Postcode      string `json:"postcode,omitempty"`
+	Country       string `json:"country,omitempty"`
+	CountryCode   string `json:"country_code,omitempty"`
+	PlaceName     string `json:"place_name,omitempty"`
+	Accuracy      string `json:"accuracy,omitempty"`
+	Maki          string `json:"maki,omitempty"`
+}
+
+type AutofillRetrieveRequest struct {
+	// required
+	ActionID     string // AutofillSuggestion.Action.ID
+	SessionToken string // must be the token of the suggest requests that returned the suggestion
+}
+
+type AutofillRetrieveResponse struct {
+	Type        string             `json:"type"`
+	Features    []*AutofillFeature `json:"features"`
+	Attribution string             `json:"attribution"`
+	URL         string             `json:"url,omitempty"`
+}
+
+type AutofillFeature struct {
+	Type       string           `json:"type"`
+	Geometry   *Geometry        `json:"geometry"`
+	Properties *AutofillAddress `json:"properties,omitempty"`
+}
+
+//////////////////////////////////////////////////////////////////
+
+// https://docs.mapbox.com/api/search/autofill/#get-address-suggestions
+func autofillSuggest(ctx context.Context, client *Client, req *AutofillSuggestRequest) (*AutofillSuggestResponse, error) {
+	if req.SessionToken == "" {
+		return nil, fmt.Errorf("missing Autofill session token")
+	}
+
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+	query.Set("session_token", req.SessionToken)
+
+	if !req.BBox.Min.IsZero() {
+		query.Set("bbox", req.BBox.query())
+	}
+
+	if req.Country != "" {
+		query.Set("country", req.Country)
+	}
+
+	if req.Language != "" {
+		query.Set("language", req.Language)
+	}
+
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if !req.Proximity.IsZero() {
+		query.Set("proximity", req.Proximity.WGS84Format())
+	}
+
+	if len(req.Types) > 0 {
+		query.Set("types", req.Types.query())
+	}
+
+	apiResponse, err := client.get(ctx, path.Join(AutofillSuggestEndpoint, url.PathEscape(req.SearchText)), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AutofillSuggestResponse
+	if err := client.handleResponse(apiResponse, &response, AutofillRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// https://docs.mapbox.com/api/search/autofill/#retrieve-a-suggestion
+func autofillRetrieve(ctx context.Context, client *Client, req *AutofillRetrieveRequest) (*AutofillRetrieveResponse, error) {
+	if req.SessionToken == "" {
+		return nil, fmt.Errorf("missing Autofill session token")
+	}
+
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+	query.Set("session_token", req.SessionToken)
+
+	apiResponse, err := client.get(ctx, path.Join(AutofillRetrieveEndpoint, url.PathEscape(req.ActionID)), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response AutofillRetrieveResponse
+	if err := client.handleResponse(apiResponse, &response, AutofillRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
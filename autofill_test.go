@@ -0,0 +1,52 @@
+package mapbox
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAutofillSuggestURLEncoding(t *testing.T) {
+	client, requests := mockClient()
+	go client.AutofillSuggest(context.Background(), &AutofillSuggestRequest{
+		SearchText:   "6005 Hidden Valley Rd #280",
+		SessionToken: "token",
+		Country:      "us",
+		Types:        Types{TypeAddress, TypeSecondaryAddress},
+	})
+
+	expected := `/autofill/v1/suggest/6005%20Hidden%20Valley%20Rd%20%23280?country=us&session_token=token&types=address%2Csecondary_address`
+	if actual := (<-requests).URL.RequestURI(); actual != expected {
+		t.Errorf("expected:\n%s, got:\n%s", expected, actual)
+	}
+}
+
+func TestAutofillRetrieve(t *testing.T) {
+	client, requests := mockClient(&http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`{"type":"FeatureCollection","features":[{"type":"Feature",
+			"geometry":{"type":"Point","coordinates":[-117.3,33.1]},
+			"properties":{"full_address":"6005 Hidden Valley Road Suite 280, Carlsbad, California 92011, United States",
+				"address_line1":"6005 Hidden Valley Road","address_line2":"Suite 280","address_level1":"CA",
+				"address_level2":"Carlsbad","postcode":"92011","country_code":"us"}}]}`)),
+	})
+
+	go func() {
+		expected := `/autofill/v1/retrieve/action-id?session_token=token`
+		if actual := (<-requests).URL.RequestURI(); actual != expected {
+			t.Errorf("expected:\n%s, got:\n%s", expected, actual)
+		}
+	}()
+
+	resp, err := client.AutofillRetrieve(context.Background(), &AutofillRetrieveRequest{ActionID: "action-id", SessionToken: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := resp.Features[0].Properties
+	if address.AddressLine2 != "Suite 280" || address.AddressLevel2 != "Carlsbad" || address.Postcode != "92011" {
+		t.Errorf("unexpected address %+v", address)
+	}
+}
@@ -0,0 +1,63 @@
+package mapbox
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// Render renders the instruction as plain text of at most width characters, no limit when
+// width is 0. Components are replaced with their abbreviations in AbbrPriority order until
+// the text fits, text which still does not fit is cut short with an ellipsis. Lane and
+// guidance view components have no text and are left out.
+func (i Instruction) Render(width int) string {
+	if len(i.Components) == 0 {
+		return truncateText(i.Text, width)
+	}
+
+	texts := make([]string, len(i.Components))
+	var abbreviations []int
+	for j, component := range i.Components {
+		if component.Type == ComponentTypeLane || component.Type == ComponentTypeGuidanceView {
+			continue
+		}
+		texts[j] = component.Text
+
+		if component.Abbr != "" {
+			abbreviations = append(abbreviations, j)
+		}
+	}
+
+	// components without a priority are abbreviated last
+	sort.SliceStable(abbreviations, func(a, b int) bool {
+		pa, pb := i.Components[abbreviations[a]].AbbrPriority, i.Components[abbreviations[b]].AbbrPriority
+		return pa != nil && (pb == nil || *pa < *pb)
+	})
+
+	text := joinNonEmpty(" ", texts...)
+	for _, j := range abbreviations {
+		if width <= 0 || utf8.RuneCountInString(text) <= width {
+			break
+		}
+		texts[j] = i.Components[j].Abbr
+		text = joinNonEmpty(" ", texts...)
+	}
+
+	return truncateText(text, width)
+}
+
+// Render renders the primary instruction, see Instruction.Render.
+func (b BannerInstruction) Render(width int) string {
+	return b.Primary.Render(width)
+}
+
+func truncateText(text string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	if width == 1 {
+		return string(runes[:1])
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,52 @@
+package mapbox
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestInstructionRender(t *testing.T) {
+	var banner BannerInstruction
+	if err := json.Unmarshal([]byte(`{
+		"distanceAlongGeometry":250,
+		"primary":{"text":"I 80 West / North Sacramento Boulevard","type":"off ramp","modifier":"slight right","components":[
+			{"text":"I 80","type":"icon","imageBaseURL":"https://example.com/shields/i-80","mapbox_shield":{"base_url":"https://api.mapbox.com/styles/v1","name":"us-interstate","text_color":"white","display_ref":"80"}},
+			{"text":"West","type":"text","abbr":"W","abbr_priority":0},
+			{"text":"/","type":"delimiter"},
+			{"text":"North","type":"text","abbr":"N","abbr_priority":1},
+			{"text":"Sacramento","type":"text"},
+			{"text":"Boulevard","type":"text","abbr":"Blvd","abbr_priority":2}
+		]},
+		"sub":{"text":"","components":[
+			{"text":"","type":"lane","directions":["straight"],"active":false},
+			{"text":"","type":"lane","directions":["straight","right"],"active":true,"active_direction":"right"}
+		]}
+	}`), &banner); err != nil {
+		t.Fatal(err)
+	}
+
+	for width, expected := range map[int]string{
+		0:  "I 80 West / North Sacramento Boulevard",
+		38: "I 80 West / North Sacramento Boulevard",
+		37: "I 80 W / North Sacramento Boulevard",
+		34: "I 80 W / N Sacramento Boulevard",
+		28: "I 80 W / N Sacramento Blvd",
+		20: "I 80 W / N Sacramen…",
+	} {
+		if actual := banner.Render(width); actual != expected {
+			t.Errorf("width %v: expected %q, got %q", width, expected, actual)
+		}
+	}
+
+	if banner.Primary.Type != ManeuverTypeOffRamp || banner.Primary.Components[0].MapboxShield.DisplayRef != "80" {
+		t.Errorf("unexpected primary instruction %+v", banner.Primary)
+	}
+
+	lanes := banner.Sub.Components
+	if lanes[1].Type != ComponentTypeLane || !*lanes[1].Active || lanes[1].ActiveDirection != LaneIndicationRight {
+		t.Errorf("unexpected lanes %+v", lanes)
+	}
+	if banner.Sub.Render(10) != "" {
+		t.Errorf("expected lanes to render as empty text, got %q", banner.Sub.Render(10))
+	}
+}
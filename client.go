@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -38,6 +39,7 @@ const (
 	MatrixRateLimit     = "matrix"
 	DirectionsRateLimit = "directions"
 	SearchboxRateLimit  = "searchbox"
+	AutofillRateLimit   = "autofill"
 )
 
 type HTTPClient interface {
@@ -85,10 +87,17 @@ type ErrorResponse struct {
 	Code    string `json:"code"`
 }
 
+// partialResponse is implemented by responses which can still carry usable results
+// alongside an error status, e.g. a batch where only some of the queries are invalid.
+type partialResponse interface {
+	partial() bool
+}
+
 type Waypoint struct {
 	Distance float64 `json:"distance"`
 	Name     string  `json:"name"`
 	Location []float64
+	Metadata *WaypointMetadata `json:"metadata,omitempty"`
 }
 
 //////////////////////////////////////////////////////////////////
@@ -128,6 +137,20 @@ func (c *Client) ForwardGeocodeBatch(ctx context.Context, req ForwardGeocodeBatc
 	return forwardGeocodeBatch(ctx, c, req)
 }
 
+func (c *Client) ForwardGeocodeV5(ctx context.Context, req *ForwardGeocodeV5Request) (*GeocodeV5Response, error) {
+	if err := c.checkRateLimit(GeocodingRateLimit); err != nil {
+		return nil, err
+	}
+	return forwardGeocodeV5(ctx, c, req)
+}
+
+func (c *Client) ReverseGeocodeV5(ctx context.Context, req *ReverseGeocodeV5Request) (*GeocodeV5Response, error) {
+	if err := c.checkRateLimit(GeocodingRateLimit); err != nil {
+		return nil, err
+	}
+	return reverseGeocodeV5(ctx, c, req)
+}
+
 func (c *Client) Directions(ctx context.Context, req *DirectionsRequest) (*DirectionsResponse, error) {
 	if err := c.checkRateLimit(DirectionsRateLimit); err != nil {
 		return nil, err
@@ -142,6 +165,55 @@ func (c *Client) SearchboxReverse(ctx context.Context, req *SearchboxReverseRequ
 	return searchboxReverse(ctx, c, req)
 }
 
+func (c *Client) SearchboxSuggest(ctx context.Context, req *SearchboxSuggestRequest) (*SearchboxSuggestResponse, error) {
+	if err := c.checkRateLimit(SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+	return searchboxSuggest(ctx, c, req)
+}
+
+func (c *Client) SearchboxRetrieve(ctx context.Context, req *SearchboxRetrieveRequest) (*SearchboxRetrieveResponse, error) {
+	if err := c.checkRateLimit(SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+	return searchboxRetrieve(ctx, c, req)
+}
+
+func (c *Client) SearchboxForward(ctx context.Context, req *SearchboxForwardRequest) (*SearchboxForwardResponse, error) {
+	if err := c.checkRateLimit(SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+	return searchboxForward(ctx, c, req)
+}
+
+func (c *Client) SearchboxCategory(ctx context.Context, req *SearchboxCategoryRequest) (*SearchboxCategoryResponse, error) {
+	if err := c.checkRateLimit(SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+	return searchboxCategory(ctx, c, req)
+}
+
+func (c *Client) SearchboxListCategories(ctx context.Context, req *SearchboxListCategoriesRequest) (*SearchboxListCategoriesResponse, error) {
+	if err := c.checkRateLimit(SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+	return searchboxListCategories(ctx, c, req)
+}
+
+func (c *Client) AutofillSuggest(ctx context.Context, req *AutofillSuggestRequest) (*AutofillSuggestResponse, error) {
+	if err := c.checkRateLimit(AutofillRateLimit); err != nil {
+		return nil, err
+	}
+	return autofillSuggest(ctx, c, req)
+}
+
+func (c *Client) AutofillRetrieve(ctx context.Context, req *AutofillRetrieveRequest) (*AutofillRetrieveResponse, error) {
+	if err := c.checkRateLimit(AutofillRateLimit); err != nil {
+		return nil, err
+	}
+	return autofillRetrieve(ctx, c, req)
+}
+
 //////////////////////////////////////////////////////////////////
 
 func (c *Client) get(ctx context.Context, relPath string, query url.Values) (*http.Response, error) {
@@ -152,7 +224,23 @@ func (c *Client) post(ctx context.Context, relPath string, query url.Values, bod
 	return c.do(ctx, http.MethodPost, relPath, query, body)
 }
 
+// postForm sends form as an application/x-www-form-urlencoded body.
+func (c *Client) postForm(ctx context.Context, relPath string, query url.Values, form url.Values) (*http.Response, error) {
+	// remove empty entries, as do does for the query
+	for k := range form {
+		if form.Get(k) == "" {
+			form.Del(k)
+		}
+	}
+
+	return c.doWithContentType(ctx, http.MethodPost, relPath, query, strings.NewReader(form.Encode()), "application/x-www-form-urlencoded")
+}
+
 func (c *Client) do(ctx context.Context, httpVerb, relPath string, query url.Values, body io.Reader) (*http.Response, error) {
+	return c.doWithContentType(ctx, httpVerb, relPath, query, body, "")
+}
+
+func (c *Client) doWithContentType(ctx context.Context, httpVerb, relPath string, query url.Values, body io.Reader, contentType string) (*http.Response, error) {
 	// remove empty entries
 	for k := range query {
 		if query.Get(k) == "" {
@@ -176,6 +264,9 @@ func (c *Client) do(ctx context.Context, httpVerb, relPath string, query url.Val
 	if c.Referer != "" {
 		req.Header.Set("Referer", c.Referer)
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	return c.httpClient.Do(req)
 }
@@ -210,6 +301,14 @@ func (c *Client) handleResponse(apiResponse *http.Response, response interface{}
 				c.rateLimits[rateLimit] = time.Unix(int64(resetUnix), 0)
 			}
 		}
+
+		// Keep whatever results came back for the valid part of the request
+		if partialResp, ok := response.(partialResponse); ok && apiResponse.StatusCode < 500 && apiResponse.StatusCode != 429 {
+			if err := json.Unmarshal(body, partialResp); err == nil && partialResp.partial() {
+				return nil
+			}
+		}
+
 		return NewMapboxError(apiResponse.StatusCode, errorResponse.Message)
 	}
 
@@ -35,6 +35,7 @@ func mockClient(responses ...*http.Response) (*Client, chan *http.Request) {
 				return resp, nil
 			}),
 		},
+		rateLimits: make(map[RateLimit]time.Time),
 	}
 	return client, ch
 }
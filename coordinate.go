@@ -2,6 +2,7 @@ package mapbox
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -49,9 +50,9 @@ type ExtendedCoordinate struct {
 }
 
 type RoutablePoint struct {
-	Name      string
-	Latitude  float64
-	Longitude float64
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -73,3 +74,17 @@ func (g Geometry) Latitude() float64 {
 func (g Geometry) Longitude() float64 {
 	return g.Coordinates[GeometryLngIdx]
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+const earthRadiusMeters = 6371008.8
+
+// DistanceTo returns the great-circle distance to o in meters.
+func (c Coordinate) DistanceTo(o Coordinate) float64 {
+	lat1, lat2 := c.Lat*math.Pi/180, o.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (o.Lng - c.Lng) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
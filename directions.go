@@ -3,12 +3,17 @@ package mapbox
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 )
 
 const (
 	directionsPath = "directions"
+
+	// DirectionsMaxURLLength is the longest GET URL the Directions API accepts, longer
+	// requests are sent as a form encoded POST instead.
+	DirectionsMaxURLLength = 8192
 )
 
 type DirectionsRequest struct {
@@ -26,6 +31,9 @@ type DirectionsRequest struct {
 	Includes            Includes
 	Overview            Overview
 	Approaches          Approaches
+	Bearings            Bearings // one per coordinate when set
+	Radiuses            Radiuses // one per coordinate when set
+	Layers              Layers   // one per coordinate when set
 	Steps               *bool
 	BannerInstructions  *bool
 	Language            string
@@ -52,15 +60,39 @@ type DirectionsRequest struct {
 	// Optional parameters for the mapbox/driving-traffic profile
 	SnappingIncludeClosures       *bool
 	SnappingIncludeStaticClosures *bool
+
+	// Optional electric vehicle routing for the mapbox/driving and mapbox/driving-traffic profiles
+	EV *DirectionsEV
 }
 
 // https://docs.mapbox.com/api/navigation/directions/#required-parameters
 func directions(ctx context.Context, client *Client, req *DirectionsRequest) (*DirectionsResponse, error) {
-	relPath := fmt.Sprintf("%v/%v/%v/%v", directionsPath, v5, req.Profile, req.Coordinates.WGS84Format())
+	if len(req.Bearings) != 0 {
+		if err := req.Bearings.validate(len(req.Coordinates)); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.Radiuses) != 0 {
+		if err := req.Radiuses.validate(len(req.Coordinates)); err != nil {
+			return nil, err
+		}
+	}
+	if len(req.Layers) != 0 {
+		if err := req.Layers.validate(len(req.Coordinates)); err != nil {
+			return nil, err
+		}
+	}
 
-	query := url.Values{}
+	if req.EV != nil {
+		if err := req.EV.validate(req.Profile); err != nil {
+			return nil, err
+		}
+	}
 
-	query.Set("access_token", client.apiKey)
+	relPath := fmt.Sprintf("%v/%v/%v", directionsPath, v5, req.Profile)
+	coordinates := req.Coordinates.WGS84Format()
+
+	query := url.Values{}
 
 	if req.Alternatives != nil {
 		query.Set("alternatives", strconv.FormatBool(*req.Alternatives))
@@ -100,6 +132,18 @@ func directions(ctx context.Context, client *Client, req *DirectionsRequest) (*D
 		query.Set("approaches", req.Approaches.query())
 	}
 
+	if len(req.Bearings) != 0 {
+		query.Set("bearings", req.Bearings.query())
+	}
+
+	if len(req.Radiuses) != 0 {
+		query.Set("radiuses", req.Radiuses.query())
+	}
+
+	if len(req.Layers) != 0 {
+		query.Set("layers", req.Layers.query())
+	}
+
 	if req.Steps != nil {
 		query.Set("steps", strconv.FormatBool(*req.Steps))
 	}
@@ -178,7 +222,11 @@ func directions(ctx context.Context, client *Client, req *DirectionsRequest) (*D
 		query.Set("snapping_include_static_closures", strconv.FormatBool(*req.SnappingIncludeStaticClosures))
 	}
 
-	apiResponse, err := client.get(ctx, relPath, query)
+	if req.EV != nil {
+		req.EV.setQuery(query)
+	}
+
+	apiResponse, err := directionsDo(ctx, client, relPath, coordinates, query)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +235,35 @@ func directions(ctx context.Context, client *Client, req *DirectionsRequest) (*D
 	if err := client.handleResponse(apiResponse, &response, DirectionsRateLimit); err != nil {
 		return nil, err
 	}
+	response.setGeometries(req.Geometries)
 
 	return &response, nil
 }
+
+// directionsDo sends the request as a GET with the coordinates in the path, or as a form
+// encoded POST with the coordinates and options in the body when the URL would be too long.
+// https://docs.mapbox.com/api/navigation/directions/#retrieve-directions
+func directionsDo(ctx context.Context, client *Client, relPath, coordinates string, options url.Values) (*http.Response, error) {
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+
+	getPath := fmt.Sprintf("%v/%v", relPath, coordinates)
+	if directionsURLLength(getPath, query, options) <= DirectionsMaxURLLength {
+		for k, v := range options {
+			query[k] = v
+		}
+		return client.get(ctx, getPath, query)
+	}
+
+	form := url.Values{}
+	form.Set("coordinates", coordinates)
+	for k, v := range options {
+		form[k] = v
+	}
+	return client.postForm(ctx, relPath, query, form)
+}
+
+func directionsURLLength(relPath string, query, options url.Values) int {
+	// baseUrl + "/" + relPath + "?" + query + "&" + options
+	return len(baseUrl) + 1 + len(relPath) + 1 + len(query.Encode()) + 1 + len(options.Encode())
+}
@@ -0,0 +1,212 @@
+package mapbox
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	EngineElectric = "electric"
+
+	ConnectorCCSComboType1 = ConnectorType("ccs_combo_type1")
+	ConnectorCCSComboType2 = ConnectorType("ccs_combo_type2")
+	ConnectorTesla         = ConnectorType("tesla")
+	ConnectorCHAdeMO       = ConnectorType("chademo")
+	ConnectorGBT           = ConnectorType("gbt")
+	ConnectorType2         = ConnectorType("type2")
+
+	WaypointTypeChargingStation = "charging-station"
+)
+
+// DirectionsEV routes an electric vehicle (engine=electric), adding charging stops where
+// needed, see https://docs.mapbox.com/api/navigation/directions/#electric-vehicle-routing
+// Only the mapbox/driving and mapbox/driving-traffic profiles are supported.
+type DirectionsEV struct {
+	// required
+	InitialCharge          float64 // Wh
+	MaxCharge              float64 // Wh
+	ConnectorTypes         ConnectorTypes
+	EnergyConsumptionCurve EnergyConsumptionCurve
+	ChargingCurve          ChargingCurve
+
+	// optional
+	MinChargeAtDestination     float64 // Wh
+	MinChargeAtChargingStation float64 // Wh
+	AuxiliaryConsumption       float64 // W, e.g. heating and air conditioning
+	MaxACChargingPower         float64 // W
+	UnconditionedChargingCurve ChargingCurve
+	PreConditioningTime        int // minutes
+}
+
+type ConnectorTypes []ConnectorType
+type ConnectorType string
+
+func (c ConnectorTypes) strings() []string {
+	res := make([]string, 0, len(c))
+
+	for _, val := range c {
+		res = append(res, string(val))
+	}
+
+	return res
+}
+
+func (c ConnectorTypes) query() string {
+	return strings.Join(c.strings(), ",")
+}
+
+// EnergyConsumptionCurve is the energy used at increasing speeds.
+type EnergyConsumptionCurve []EnergyConsumption
+
+type EnergyConsumption struct {
+	Speed       float64 // km/h
+	Consumption float64 // Wh/km
+}
+
+func (e EnergyConsumptionCurve) query() string {
+	res := make([]string, 0, len(e))
+
+	for _, val := range e {
+		res = append(res, formatFloat(val.Speed)+","+formatFloat(val.Consumption))
+	}
+
+	return strings.Join(res, ";")
+}
+
+// ChargingCurve is the charging power at increasing battery charge.
+type ChargingCurve []ChargingPower
+
+type ChargingPower struct {
+	Charge float64 // Wh
+	Power  float64 // W
+}
+
+func (c ChargingCurve) query() string {
+	res := make([]string, 0, len(c))
+
+	for _, val := range c {
+		res = append(res, formatFloat(val.Charge)+","+formatFloat(val.Power))
+	}
+
+	return strings.Join(res, ";")
+}
+
+func (e *DirectionsEV) validate(profile Profile) error {
+	if profile != ProfileDriving && profile != ProfileDrivingTraffic {
+		return fmt.Errorf("electric vehicle routing is not supported for %v", profile)
+	}
+
+	switch {
+	case e.InitialCharge <= 0:
+		return fmt.Errorf("missing electric vehicle initial charge")
+	case e.MaxCharge <= 0:
+		return fmt.Errorf("missing electric vehicle max charge")
+	case len(e.ConnectorTypes) == 0:
+		return fmt.Errorf("missing electric vehicle connector types")
+	case len(e.EnergyConsumptionCurve) == 0:
+		return fmt.Errorf("missing electric vehicle energy consumption curve")
+	case len(e.ChargingCurve) == 0:
+		return fmt.Errorf("missing electric vehicle charging curve")
+	}
+
+	if e.InitialCharge > e.MaxCharge {
+		return fmt.Errorf("initial charge %v exceeds max charge %v", e.InitialCharge, e.MaxCharge)
+	}
+
+	for i := 1; i < len(e.EnergyConsumptionCurve); i++ {
+		if e.EnergyConsumptionCurve[i].Speed <= e.EnergyConsumptionCurve[i-1].Speed {
+			return fmt.Errorf("energy consumption curve speeds must be increasing")
+		}
+	}
+
+	for _, curve := range []ChargingCurve{e.ChargingCurve, e.UnconditionedChargingCurve} {
+		for i := 1; i < len(curve); i++ {
+			if curve[i].Charge <= curve[i-1].Charge {
+				return fmt.Errorf("charging curve charges must be increasing")
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *DirectionsEV) setQuery(query url.Values) {
+	query.Set("engine", EngineElectric)
+
+	for key, value := range map[string]float64{
+		"ev_initial_charge":                 e.InitialCharge,
+		"ev_max_charge":                     e.MaxCharge,
+		"ev_min_charge_at_destination":      e.MinChargeAtDestination,
+		"ev_min_charge_at_charging_station": e.MinChargeAtChargingStation,
+		"auxiliary_consumption":             e.AuxiliaryConsumption,
+		"ev_max_ac_charging_power":          e.MaxACChargingPower,
+	} {
+		if value != 0 {
+			query.Set(key, formatFloat(value))
+		}
+	}
+
+	if len(e.ConnectorTypes) != 0 {
+		query.Set("ev_connector_types", e.ConnectorTypes.query())
+	}
+
+	if len(e.EnergyConsumptionCurve) != 0 {
+		query.Set("energy_consumption_curve", e.EnergyConsumptionCurve.query())
+	}
+
+	if len(e.ChargingCurve) != 0 {
+		query.Set("ev_charging_curve", e.ChargingCurve.query())
+	}
+
+	if len(e.UnconditionedChargingCurve) != 0 {
+		query.Set("ev_unconditioned_charging_curve", e.UnconditionedChargingCurve.query())
+	}
+
+	if e.PreConditioningTime != 0 {
+		query.Set("ev_pre_conditioning_time", strconv.Itoa(e.PreConditioningTime))
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+//////////////////////////////////////////////////////////////////
+
+// WaypointMetadata describes a waypoint added by the API, e.g. a charging stop.
+type WaypointMetadata struct {
+	Type            string   `json:"type"` // e.g. WaypointTypeChargingStation
+	Name            string   `json:"name,omitempty"`
+	ChargeTime      float64  `json:"charge_time,omitempty"`       // seconds
+	ChargeTo        float64  `json:"charge_to,omitempty"`         // Wh
+	ChargeAtArrival float64  `json:"charge_at_arrival,omitempty"` // Wh
+	PlugType        string   `json:"plug_type,omitempty"`
+	PowerKW         float64  `json:"power_kw,omitempty"`
+	StationID       string   `json:"station_id,omitempty"`
+	ProviderNames   []string `json:"provider_names,omitempty"`
+}
+
+// ChargingStations returns the charging stops the API added to the route.
+func (r *Route) ChargingStations() []Waypoint {
+	return chargingStations(r.Waypoints)
+}
+
+// ChargingStations returns the charging stops the API added to the routes, when
+// WaypointsPerRoute was not set.
+func (r *DirectionsResponse) ChargingStations() []Waypoint {
+	return chargingStations(r.Waypoints)
+}
+
+func chargingStations(waypoints []Waypoint) []Waypoint {
+	var res []Waypoint
+
+	for _, waypoint := range waypoints {
+		if waypoint.Metadata != nil && waypoint.Metadata.Type == WaypointTypeChargingStation {
+			res = append(res, waypoint)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,185 @@
+package mapbox
+
+import (
+	"fmt"
+)
+
+// GeometrySpan is an inclusive range of indexes into the geometry of a leg.
+type GeometrySpan struct {
+	Start int
+	End   int
+}
+
+func (c Closure) Span() GeometrySpan {
+	return GeometrySpan{Start: c.GeometryIndexStart, End: c.GeometryIndexEnd}
+}
+
+func (i Incident) Span() GeometrySpan {
+	return GeometrySpan{Start: i.GeometryIndexStart, End: i.GeometryIndexEnd}
+}
+
+// Span returns the part of the leg the notification is about, with false when it is about
+// the whole leg.
+func (n Notification) Span() (GeometrySpan, bool) {
+	switch {
+	case n.GeometryIndexStart != nil && n.GeometryIndexEnd != nil:
+		return GeometrySpan{Start: *n.GeometryIndexStart, End: *n.GeometryIndexEnd}, true
+	case n.GeometryIndex != nil:
+		return GeometrySpan{Start: *n.GeometryIndex, End: *n.GeometryIndex}, true
+	}
+	return GeometrySpan{}, false
+}
+
+func (s GeometrySpan) overlaps(o GeometrySpan) bool {
+	return s.Start <= o.End && o.Start <= s.End
+}
+
+// LegLocation is where a span of a leg's geometry lies, see RouteLeg.Locate.
+type LegLocation struct {
+	Span        GeometrySpan
+	Steps       []int // indexes into RouteLeg.Steps
+	Coordinates Coordinates
+}
+
+// IncidentLocation is an incident with the part of the leg it affects.
+type IncidentLocation struct {
+	Incident Incident
+	LegLocation
+}
+
+// ClosureLocation is a closure with the part of the leg it closes.
+type ClosureLocation struct {
+	Closure Closure
+	LegLocation
+}
+
+// NotificationLocation is a notification with the part of the leg it is about.
+type NotificationLocation struct {
+	Notification Notification
+	LegLocation
+}
+
+// Coordinates returns the geometry of the leg, which the geometry indexes of incidents,
+// closures and notifications refer to. The request must have set Steps.
+func (l *RouteLeg) Coordinates() (Coordinates, error) {
+	coordinates, _, err := l.stepGeometry()
+	return coordinates, err
+}
+
+// StepSpans returns the part of the leg's geometry each step covers. The request must
+// have set Steps.
+func (l *RouteLeg) StepSpans() ([]GeometrySpan, error) {
+	_, spans, err := l.stepGeometry()
+	return spans, err
+}
+
+// Locate finds the steps and coordinates a span of the leg's geometry covers.
+func (l *RouteLeg) Locate(span GeometrySpan) (LegLocation, error) {
+	coordinates, spans, err := l.stepGeometry()
+	if err != nil {
+		return LegLocation{}, err
+	}
+
+	if span.Start < 0 || span.End < span.Start || span.End >= len(coordinates) {
+		return LegLocation{}, fmt.Errorf("geometry span %v-%v out of range for %v coordinates", span.Start, span.End, len(coordinates))
+	}
+
+	location := LegLocation{Span: span, Coordinates: coordinates[span.Start : span.End+1]}
+	for i, stepSpan := range spans {
+		if stepSpan.overlaps(span) {
+			location.Steps = append(location.Steps, i)
+		}
+	}
+
+	return location, nil
+}
+
+// LocateIncidents locates every incident of the leg, see Locate.
+func (l *RouteLeg) LocateIncidents() ([]IncidentLocation, error) {
+	res := make([]IncidentLocation, 0, len(l.Incidents))
+
+	for _, incident := range l.Incidents {
+		location, err := l.Locate(incident.Span())
+		if err != nil {
+			return nil, fmt.Errorf("incident %v: %w", incident.ID, err)
+		}
+		res = append(res, IncidentLocation{Incident: incident, LegLocation: location})
+	}
+
+	return res, nil
+}
+
+// LocateClosures locates every closure of the leg, see Locate.
+func (l *RouteLeg) LocateClosures() ([]ClosureLocation, error) {
+	res := make([]ClosureLocation, 0, len(l.Closures))
+
+	for i, closure := range l.Closures {
+		location, err := l.Locate(closure.Span())
+		if err != nil {
+			return nil, fmt.Errorf("closure %v: %w", i, err)
+		}
+		res = append(res, ClosureLocation{Closure: closure, LegLocation: location})
+	}
+
+	return res, nil
+}
+
+// LocateNotifications locates every notification of the leg, see Locate. Notifications
+// about the whole leg are located on the whole leg.
+func (l *RouteLeg) LocateNotifications() ([]NotificationLocation, error) {
+	res := make([]NotificationLocation, 0, len(l.Notifications))
+
+	for i, notification := range l.Notifications {
+		span, ok := notification.Span()
+		if !ok {
+			coordinates, _, err := l.stepGeometry()
+			if err != nil {
+				return nil, err
+			}
+			span = GeometrySpan{Start: 0, End: len(coordinates) - 1}
+		}
+
+		location, err := l.Locate(span)
+		if err != nil {
+			return nil, fmt.Errorf("notification %v: %w", i, err)
+		}
+		res = append(res, NotificationLocation{Notification: notification, LegLocation: location})
+	}
+
+	return res, nil
+}
+
+// stepGeometry joins the step geometries into the leg geometry. Consecutive steps share
+// their boundary coordinate, and steps that stay in place, like the arrive step, repeat it.
+func (l *RouteLeg) stepGeometry() (Coordinates, []GeometrySpan, error) {
+	if len(l.Steps) == 0 {
+		return nil, nil, fmt.Errorf("leg has no steps, set Steps on the request")
+	}
+
+	var coordinates Coordinates
+	spans := make([]GeometrySpan, 0, len(l.Steps))
+
+	for i, step := range l.Steps {
+		stepCoordinates, err := step.Geometry.Coordinates()
+		if err != nil {
+			return nil, nil, fmt.Errorf("step %v: %w", i, err)
+		}
+		if len(stepCoordinates) == 0 {
+			return nil, nil, fmt.Errorf("step %v has no geometry", i)
+		}
+
+		if len(coordinates) == 0 {
+			coordinates = append(coordinates, stepCoordinates[0])
+		}
+		start := len(coordinates) - 1
+
+		added := stepCoordinates[1:]
+		if len(added) == 1 && added[0] == coordinates[start] {
+			added = nil
+		}
+		coordinates = append(coordinates, added...)
+		spans = append(spans, GeometrySpan{Start: start, End: len(coordinates) - 1})
+	}
+
+	return coordinates, spans, nil
+}
@@ -4,19 +4,36 @@ type DirectionsResponse struct {
 	Code   string  `json:"code"`
 	UUID   string  `json:"uuid,omitempty"`
 	Routes []Route `json:"routes"`
+
+	// Waypoints are the snapped coordinates and any charging stops, unless WaypointsPerRoute was set.
+	Waypoints []Waypoint `json:"waypoints,omitempty"`
 }
 
 type Route struct {
-	Duration        float64    `json:"duration"`
-	Distance        float64    `json:"distance"`
-	WeightName      string     `json:"weight_name"`
-	Weight          float64    `json:"weight"`
-	DurationTypical float64    `json:"duration_typical,omitempty"`
-	WeightTypical   float64    `json:"weight_typical,omitempty"`
-	Geometry        string     `json:"geometry,omitempty"`
-	Legs            []RouteLeg `json:"legs"`
-	VoiceLocale     string     `json:"voiceLocale,omitempty"`
-	Waypoints       []Waypoint `json:"waypoints,omitempty"`
+	Duration        float64       `json:"duration"`
+	Distance        float64       `json:"distance"`
+	WeightName      string        `json:"weight_name"`
+	Weight          float64       `json:"weight"`
+	DurationTypical float64       `json:"duration_typical,omitempty"`
+	WeightTypical   float64       `json:"weight_typical,omitempty"`
+	Geometry        RouteGeometry `json:"geometry"`
+	Legs            []RouteLeg    `json:"legs"`
+	VoiceLocale     string        `json:"voiceLocale,omitempty"`
+	Waypoints       []Waypoint    `json:"waypoints,omitempty"`
+}
+
+// setGeometries tells every geometry in the response which format was requested.
+func (r *DirectionsResponse) setGeometries(requested Geometries) {
+	for i := range r.Routes {
+		route := &r.Routes[i]
+		route.Geometry.setFormat(requested)
+
+		for j := range route.Legs {
+			for k := range route.Legs[j].Steps {
+				route.Legs[j].Steps[k].Geometry.setFormat(requested)
+			}
+		}
+	}
 }
 
 // RouteLeg represents a leg of the route between two waypoints.
@@ -31,13 +48,16 @@ type RouteLeg struct {
 	VoiceInstructions  []VoiceInstruction   `json:"voiceInstructions"`  // An array of VoiceInstruction objects.
 	BannerInstructions []BannerInstruction  `json:"bannerInstructions"` // An array of BannerInstruction objects.
 	ViaWaypoints       []ViaWaypoint        `json:"via_waypoints"`
+	Closures           []Closure            `json:"closures,omitempty"`      // Road closures along the leg, see AnnotationClosure.
+	Incidents          []Incident           `json:"incidents,omitempty"`     // Traffic incidents along the leg, mapbox/driving-traffic only.
+	Notifications      []Notification       `json:"notifications,omitempty"` // Alerts and violated restrictions along the leg.
 }
 
 // Step represents a single step in a leg of a route, containing maneuver instructions and distance/duration.
 type Step struct {
 	Distance      float64        `json:"distance"`      // The distance for this step in meters.
 	Duration      float64        `json:"duration"`      // The estimated travel time for this step in seconds.
-	Geometry      string         `json:"geometry"`      // An encoded polyline string or GeoJSON LineString representing the step geometry.
+	Geometry      RouteGeometry  `json:"geometry"`      // An encoded polyline string or GeoJSON LineString representing the step geometry.
 	Name          string         `json:"name"`          // The name of the road or path used in the step.
 	Maneuver      Maneuver       `json:"maneuver"`      // The maneuver required to move from this step to the next.
 	Mode          string         `json:"mode"`          // The travel mode of the step.
@@ -47,21 +67,55 @@ type Step struct {
 
 // Maneuver contains information about the required maneuver for a step, including type and bearing.
 type Maneuver struct {
-	BearingAfter  float64   `json:"bearing_after"`  // The clockwise angle from true north to the direction of travel after the maneuver.
-	BearingBefore float64   `json:"bearing_before"` // The clockwise angle from true north to the direction of travel before the maneuver.
-	Location      []float64 `json:"location"`       // A [longitude, latitude] pair describing the location of the maneuver.
-	Type          string    `json:"type"`           // A string signifying the type of maneuver. Example: "turn".
-	Modifier      string    `json:"modifier"`       // An additional modifier to provide more detail. Example: "left".
-	Instruction   string    `json:"instruction"`    // Verbal instruction for the maneuver.
+	BearingAfter  float64          `json:"bearing_after"`  // The clockwise angle from true north to the direction of travel after the maneuver.
+	BearingBefore float64          `json:"bearing_before"` // The clockwise angle from true north to the direction of travel before the maneuver.
+	Location      []float64        `json:"location"`       // A [longitude, latitude] pair describing the location of the maneuver.
+	Type          ManeuverType     `json:"type"`           // The type of maneuver. Example: ManeuverTypeTurn.
+	Modifier      ManeuverModifier `json:"modifier"`       // An additional modifier to provide more detail. Example: ManeuverModifierLeft.
+	Instruction   string           `json:"instruction"`    // Verbal instruction for the maneuver.
+	Exit          int              `json:"exit,omitempty"` // The exit to take on a roundabout or rotary, starting from 1.
 }
 
+const (
+	ManeuverTypeTurn           = ManeuverType("turn")
+	ManeuverTypeNewName        = ManeuverType("new name")
+	ManeuverTypeDepart         = ManeuverType("depart")
+	ManeuverTypeArrive         = ManeuverType("arrive")
+	ManeuverTypeMerge          = ManeuverType("merge")
+	ManeuverTypeOnRamp         = ManeuverType("on ramp")
+	ManeuverTypeOffRamp        = ManeuverType("off ramp")
+	ManeuverTypeFork           = ManeuverType("fork")
+	ManeuverTypeEndOfRoad      = ManeuverType("end of road")
+	ManeuverTypeContinue       = ManeuverType("continue")
+	ManeuverTypeRoundabout     = ManeuverType("roundabout")
+	ManeuverTypeRotary         = ManeuverType("rotary")
+	ManeuverTypeRoundaboutTurn = ManeuverType("roundabout turn")
+	ManeuverTypeNotification   = ManeuverType("notification")
+	ManeuverTypeExitRoundabout = ManeuverType("exit roundabout")
+	ManeuverTypeExitRotary     = ManeuverType("exit rotary")
+
+	ManeuverModifierUturn       = ManeuverModifier("uturn")
+	ManeuverModifierSharpRight  = ManeuverModifier("sharp right")
+	ManeuverModifierRight       = ManeuverModifier("right")
+	ManeuverModifierSlightRight = ManeuverModifier("slight right")
+	ManeuverModifierStraight    = ManeuverModifier("straight")
+	ManeuverModifierSlightLeft  = ManeuverModifier("slight left")
+	ManeuverModifierLeft        = ManeuverModifier("left")
+	ManeuverModifierSharpLeft   = ManeuverModifier("sharp left")
+)
+
+type ManeuverType string
+type ManeuverModifier string
+
 // Annotation contains additional details about each point along the route leg.
 type DirectionsAnnotation struct {
-	Distance   []float64  `json:"distance"`   // Array of distances between each pair of coordinates.
-	Duration   []float64  `json:"duration"`   // Array of expected travel times from each coordinate to the next.
-	Speed      []float64  `json:"speed"`      // Array of travel speeds.
-	Congestion []string   `json:"congestion"` // Array of congestion levels.
-	Maxspeed   []Maxspeed `json:"maxspeed"`
+	Distance          []float64  `json:"distance"`           // Array of distances between each pair of coordinates.
+	Duration          []float64  `json:"duration"`           // Array of expected travel times from each coordinate to the next.
+	Speed             []float64  `json:"speed"`              // Array of travel speeds.
+	Congestion        []string   `json:"congestion"`         // Array of congestion levels.
+	CongestionNumeric []*int     `json:"congestion_numeric"` // Array of congestion levels from 0 to 100, nil where unknown.
+	Maxspeed          []Maxspeed `json:"maxspeed"`           // Array of speed limits.
+	StateOfCharge     []float64  `json:"state_of_charge"`    // Array of battery charge percentages, electric vehicles only.
 }
 
 // Admin represents administrative region information.
@@ -82,29 +136,136 @@ type BannerInstruction struct {
 	DistanceAlongGeometry float64      `json:"distanceAlongGeometry"` // The distance from the current step at which to show the instruction.
 	Primary               Instruction  `json:"primary"`               // The primary instruction for this step.
 	Secondary             *Instruction `json:"secondary,omitempty"`   // An optional secondary instruction.
+	Sub                   *Instruction `json:"sub,omitempty"`         // An optional instruction below the primary one, e.g. lanes or the next maneuver.
+	View                  *Instruction `json:"view,omitempty"`        // An optional guidance view image of the junction.
 }
 
 // Instruction contains the details of a navigation instruction.
 type Instruction struct {
-	Text       string      `json:"text"`       // The instruction text.
-	Type       string      `json:"type"`       // The type of maneuver.
-	Modifier   string      `json:"modifier"`   // An additional modifier to provide more detail.
-	Components []Component `json:"components"` // Components of the instruction.
+	Text        string           `json:"text"`                   // The instruction text.
+	Type        ManeuverType     `json:"type"`                   // The type of maneuver.
+	Modifier    ManeuverModifier `json:"modifier"`               // An additional modifier to provide more detail.
+	Degrees     float64          `json:"degrees,omitempty"`      // The degrees to travel around a roundabout or rotary.
+	DrivingSide string           `json:"driving_side,omitempty"` // "left" or "right".
+	Components  []Component      `json:"components"`             // Components of the instruction.
 }
 
+const (
+	ComponentTypeText         = ComponentType("text")
+	ComponentTypeIcon         = ComponentType("icon")
+	ComponentTypeDelimiter    = ComponentType("delimiter")
+	ComponentTypeExit         = ComponentType("exit")
+	ComponentTypeExitNumber   = ComponentType("exit-number")
+	ComponentTypeLane         = ComponentType("lane")
+	ComponentTypeGuidanceView = ComponentType("guidance-view")
+)
+
+type ComponentType string
+
 // Component represents a part of the instruction, useful for highlighting parts of the text.
 type Component struct {
-	Text string `json:"text"` // The component text.
-	Type string `json:"type"` // The type of component, e.g., "text" or "icon".
+	Text            string           `json:"text"`                       // The component text.
+	Type            ComponentType    `json:"type"`                       // The type of component, e.g., ComponentTypeText or ComponentTypeIcon.
+	Abbr            string           `json:"abbr,omitempty"`             // An abbreviation of the text.
+	AbbrPriority    *int             `json:"abbr_priority,omitempty"`    // The order to abbreviate components in, starting with 0.
+	ImageBaseURL    string           `json:"imageBaseURL,omitempty"`     // The base URL of a road shield image for icon components.
+	ImageURL        string           `json:"imageURL,omitempty"`         // The URL of a guidance view image.
+	MapboxShield    *MapboxShield    `json:"mapbox_shield,omitempty"`    // A road shield from the Mapbox shield sprites for icon components.
+	Directions      []LaneIndication `json:"directions,omitempty"`       // The turn arrows of a lane component.
+	Active          *bool            `json:"active,omitempty"`           // Whether the lane component can be used for the maneuver.
+	ActiveDirection LaneIndication   `json:"active_direction,omitempty"` // The arrow of the lane component to follow.
+	SubType         string           `json:"subType,omitempty"`          // The kind of guidance view, e.g. "jct" or "signboard".
+}
+
+// MapboxShield identifies a road shield, see https://docs.mapbox.com/api/navigation/directions/#banner-instruction-object
+type MapboxShield struct {
+	BaseURL    string `json:"base_url"`
+	Name       string `json:"name"`
+	TextColor  string `json:"text_color"`
+	DisplayRef string `json:"display_ref"`
 }
 
 // Intersection represents an intersection along a step.
 type Intersection struct {
-	Location []float64 `json:"location"`      // The location of the intersection [longitude, latitude].
-	Bearings []int     `json:"bearings"`      // The bearings at the intersection, in degrees.
-	Entry    []bool    `json:"entry"`         // A boolean flag indicating the availability of the corresponding bearing.
-	In       int       `json:"in,omitempty"`  // The index into the bearings/entry array that denotes the incoming bearing to the intersection.
-	Out      int       `json:"out,omitempty"` // The index into the bearings/entry array that denotes the outgoing bearing from the intersection.
+	Location        []float64           `json:"location"`                   // The location of the intersection [longitude, latitude].
+	Bearings        []int               `json:"bearings"`                   // The bearings at the intersection, in degrees.
+	Entry           []bool              `json:"entry"`                      // A boolean flag indicating the availability of the corresponding bearing.
+	In              int                 `json:"in,omitempty"`               // The index into the bearings/entry array that denotes the incoming bearing to the intersection.
+	Out             int                 `json:"out,omitempty"`              // The index into the bearings/entry array that denotes the outgoing bearing from the intersection.
+	Classes         []RoadClass         `json:"classes,omitempty"`          // The classes of the road following the intersection.
+	Lanes           []Lane              `json:"lanes,omitempty"`            // The lanes at the intersection, from left to right.
+	GeometryIndex   *int                `json:"geometry_index,omitempty"`   // The index of the intersection into the leg geometry.
+	IsUrban         *bool               `json:"is_urban,omitempty"`         // Whether the intersection is in an urban area.
+	AdminIndex      *int                `json:"admin_index,omitempty"`      // The index into the leg's Admins.
+	RestStop        *RestStop           `json:"rest_stop,omitempty"`        // A rest stop reached from the intersection.
+	TollCollection  *TollCollection     `json:"toll_collection,omitempty"`  // A toll booth or gantry at the intersection.
+	TunnelName      string              `json:"tunnel_name,omitempty"`      // The name of the tunnel following the intersection.
+	TrafficSignal   bool                `json:"traffic_signal,omitempty"`   // Whether there is a traffic signal at the intersection.
+	StopSign        bool                `json:"stop_sign,omitempty"`        // Whether there is a stop sign at the intersection.
+	YieldSign       bool                `json:"yield_sign,omitempty"`       // Whether there is a yield sign at the intersection.
+	RailwayCrossing bool                `json:"railway_crossing,omitempty"` // Whether there is a railway crossing at the intersection.
+	MapboxStreetsV8 *MapboxStreetsClass `json:"mapbox_streets_v8,omitempty"`
+}
+
+// HasClass reports whether the road following the intersection has the class.
+func (i Intersection) HasClass(class RoadClass) bool {
+	for _, c := range i.Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	RoadClassToll       = RoadClass("toll")
+	RoadClassFerry      = RoadClass("ferry")
+	RoadClassRestricted = RoadClass("restricted")
+	RoadClassMotorway   = RoadClass("motorway")
+	RoadClassTunnel     = RoadClass("tunnel")
+
+	LaneIndicationNone        = LaneIndication("none")
+	LaneIndicationUturn       = LaneIndication("uturn")
+	LaneIndicationSharpRight  = LaneIndication("sharp right")
+	LaneIndicationRight       = LaneIndication("right")
+	LaneIndicationSlightRight = LaneIndication("slight right")
+	LaneIndicationStraight    = LaneIndication("straight")
+	LaneIndicationSlightLeft  = LaneIndication("slight left")
+	LaneIndicationLeft        = LaneIndication("left")
+	LaneIndicationSharpLeft   = LaneIndication("sharp left")
+
+	RestStopTypeRestArea    = "rest_area"
+	RestStopTypeServiceArea = "service_area"
+
+	TollCollectionTypeBooth  = "toll_booth"
+	TollCollectionTypeGantry = "toll_gantry"
+)
+
+type RoadClass string
+type LaneIndication string
+
+// Lane is a lane at an intersection. Valid lanes can be used to complete the maneuver,
+// the active lanes are the preferred ones.
+type Lane struct {
+	Valid           bool             `json:"valid"`
+	Active          bool             `json:"active"`
+	ValidIndication LaneIndication   `json:"valid_indication,omitempty"` // The indication to follow when the lane is valid.
+	Indications     []LaneIndication `json:"indications"`                // The turn arrows painted on the lane.
+}
+
+type RestStop struct {
+	Type string `json:"type"` // RestStopTypeRestArea or RestStopTypeServiceArea
+	Name string `json:"name,omitempty"`
+}
+
+type TollCollection struct {
+	Type string `json:"type"` // TollCollectionTypeBooth or TollCollectionTypeGantry
+	Name string `json:"name,omitempty"`
+}
+
+// MapboxStreetsClass is the road class of the Mapbox Streets v8 tileset, e.g. "primary".
+type MapboxStreetsClass struct {
+	Class string `json:"class"`
 }
 
 type ViaWaypoint struct {
@@ -113,7 +274,90 @@ type ViaWaypoint struct {
 	GeometryIndex     int     `json:"geometry_index"`
 }
 
+const (
+	MaxspeedUnitKPH = "km/h"
+	MaxspeedUnitMPH = "mph"
+)
+
+// Maxspeed is the speed limit of a segment. Unknown is set when the limit is not known,
+// None when there is no limit, e.g. on parts of the German Autobahn.
 type Maxspeed struct {
-	Speed int    `json:"speed"`
-	Unit  string `json:"unit"`
+	Speed   int    `json:"speed,omitempty"`
+	Unit    string `json:"unit,omitempty"`
+	Unknown bool   `json:"unknown,omitempty"`
+	None    bool   `json:"none,omitempty"`
+}
+
+// IsKnown reports whether the segment has a speed limit which is known.
+func (m Maxspeed) IsKnown() bool {
+	return !m.Unknown && !m.None && m.Unit != ""
+}
+
+// KPH returns the speed limit in km/h, with false when there is no known limit.
+func (m Maxspeed) KPH() (float64, bool) {
+	if !m.IsKnown() {
+		return 0, false
+	}
+	if m.Unit == MaxspeedUnitMPH {
+		return float64(m.Speed) * 1.609344, true
+	}
+	return float64(m.Speed), true
+}
+
+// Closure is a closed section of a leg, between two indexes into the leg's geometry.
+type Closure struct {
+	GeometryIndexStart int `json:"geometry_index_start"`
+	GeometryIndexEnd   int `json:"geometry_index_end"`
+}
+
+// Incident is a traffic incident along a leg.
+type Incident struct {
+	ID                 string              `json:"id"`
+	Type               string              `json:"type"` // e.g. "accident", "construction", "road_closure"
+	Description        string              `json:"description,omitempty"`
+	LongDescription    string              `json:"long_description,omitempty"`
+	CreationTime       string              `json:"creation_time,omitempty"`
+	StartTime          string              `json:"start_time,omitempty"`
+	EndTime            string              `json:"end_time,omitempty"`
+	Impact             string              `json:"impact,omitempty"` // "unknown", "critical", "major", "minor" or "low"
+	SubType            string              `json:"sub_type,omitempty"`
+	SubTypeDescription string              `json:"sub_type_description,omitempty"`
+	AlertcCodes        []int               `json:"alertc_codes,omitempty"`
+	LanesBlocked       []string            `json:"lanes_blocked,omitempty"`
+	Length             float64             `json:"length,omitempty"`
+	Congestion         *IncidentCongestion `json:"congestion,omitempty"`
+	Closed             bool                `json:"closed,omitempty"`
+	GeometryIndexStart int                 `json:"geometry_index_start"`
+	GeometryIndexEnd   int                 `json:"geometry_index_end"`
+	AffectedRoadNames  []string            `json:"affected_road_names,omitempty"`
+	CountryCodeAlpha2  string              `json:"iso_3166_1_alpha2,omitempty"`
+	CountryCodeAlpha3  string              `json:"iso_3166_1_alpha3,omitempty"`
+}
+
+type IncidentCongestion struct {
+	Value int `json:"value"` // 0 to 100
+}
+
+const (
+	NotificationTypeAlert     = "alert"
+	NotificationTypeViolation = "violation"
+)
+
+// Notification warns about a leg, e.g. a violated max height restriction. The geometry
+// indexes are only set for notifications about part of the leg.
+type Notification struct {
+	Type               string               `json:"type"`    // NotificationTypeAlert or NotificationTypeViolation
+	Subtype            string               `json:"subtype"` // e.g. "maxHeight", "maxWeight", "evInsufficientCharge"
+	RefreshType        string               `json:"refresh_type,omitempty"`
+	GeometryIndex      *int                 `json:"geometry_index,omitempty"`
+	GeometryIndexStart *int                 `json:"geometry_index_start,omitempty"`
+	GeometryIndexEnd   *int                 `json:"geometry_index_end,omitempty"`
+	Details            *NotificationDetails `json:"details,omitempty"`
+}
+
+type NotificationDetails struct {
+	RequestedValue interface{} `json:"requested_value,omitempty"`
+	ActualValue    interface{} `json:"actual_value,omitempty"`
+	Unit           string      `json:"unit,omitempty"`
+	Message        string      `json:"message,omitempty"`
 }
@@ -0,0 +1,108 @@
+package mapbox
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DirectionsStop holds every per-coordinate option of a Directions request, see
+// DirectionsRequest.SetStops.
+type DirectionsStop struct {
+	Coordinate Coordinate
+	Name       WaypointName
+	Approach   Approach
+	Bearing    *Bearing
+	Radius     Radius
+	Layer      *Layer
+	Target     Coordinate // drop-off location, e.g. a routable point
+	Via        bool       // pass through without splitting the route into another leg
+}
+
+// SetStops replaces the coordinates and every per-coordinate list of the request with the
+// stops. A list is only set when at least one stop uses its option, the other stops get an
+// empty slot. Via stops are left out of Waypoints and WaypointNames, so the first and last
+// stop can not be vias and vias can not have names. Stops with a Target also enable Steps,
+// which the API requires for waypoint targets.
+func (r *DirectionsRequest) SetStops(stops []DirectionsStop) error {
+	if len(stops) < 2 {
+		return fmt.Errorf("expected at least 2 stops, got %v", len(stops))
+	}
+	if stops[0].Via || stops[len(stops)-1].Via {
+		return fmt.Errorf("the first and last stop can not be vias")
+	}
+
+	var (
+		coordinates = make(Coordinates, len(stops))
+		names       = make(WaypointNames, 0, len(stops))
+		approaches  = make(Approaches, len(stops))
+		bearings    = make(Bearings, len(stops))
+		radiuses    = make(Radiuses, len(stops))
+		layers      = make(Layers, len(stops))
+		targets     = make(WaypointTargets, len(stops))
+		waypoints   = make(DirectionWaypoints, 0, len(stops))
+
+		hasNames, hasApproaches, hasBearings, hasRadiuses, hasLayers, hasTargets, hasVias bool
+	)
+
+	for i, stop := range stops {
+		coordinates[i] = stop.Coordinate
+
+		hasNames = hasNames || stop.Name != ""
+
+		approaches[i] = stop.Approach
+		hasApproaches = hasApproaches || stop.Approach != ""
+
+		bearings[i] = stop.Bearing
+		hasBearings = hasBearings || stop.Bearing != nil
+
+		radiuses[i] = stop.Radius
+		hasRadiuses = hasRadiuses || stop.Radius != RadiusDefault
+
+		layers[i] = stop.Layer
+		hasLayers = hasLayers || stop.Layer != nil
+
+		if !stop.Target.IsZero() {
+			targets[i] = WaypointTarget(stop.Target.WGS84Format())
+			hasTargets = true
+		}
+
+		if stop.Via {
+			// waypoint_names only has an entry per waypoint
+			if stop.Name != "" {
+				return fmt.Errorf("via stop %v can not have a name", i)
+			}
+			hasVias = true
+			continue
+		}
+		waypoints = append(waypoints, DirectionWaypoint(strconv.Itoa(i)))
+		names = append(names, stop.Name)
+	}
+
+	r.Coordinates = coordinates
+	r.WaypointNames, r.Approaches, r.Bearings, r.Radiuses, r.Layers, r.WaypointTargets, r.Waypoints = nil, nil, nil, nil, nil, nil, nil
+
+	if hasNames {
+		r.WaypointNames = names
+	}
+	if hasApproaches {
+		r.Approaches = approaches
+	}
+	if hasBearings {
+		r.Bearings = bearings
+	}
+	if hasRadiuses {
+		r.Radiuses = radiuses
+	}
+	if hasLayers {
+		r.Layers = layers
+	}
+	if hasTargets {
+		r.WaypointTargets = targets
+		r.enableSteps()
+	}
+	if hasVias {
+		r.Waypoints = waypoints
+	}
+
+	return nil
+}
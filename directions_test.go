@@ -2,6 +2,14 @@ package mapbox
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -48,3 +56,483 @@ func TestForwardDirectionsURLEncoding(t *testing.T) {
 		SnappingIncludeStaticClosures: &trueVal,
 	}, `/directions/v5/mapbox/driving-traffic/-117.306786,33.122508;-117.193443,32.73381?alternatives=true&annotations=distance%2Cduration&approaches=unrestricted&avoid_maneuver_radius=1&banner_instructions=true&continue_straight=true&exclude=unpaved%2Ccash_only_tolls&geometries=geojson&include=hov2%2Chot&language=en&overview=full&roundabout_exits=true&snapping_include_closures=true&snapping_include_static_closures=true&steps=true&voice_instructions=true&voice_units=metric&waypoint_names=wp1%3Bwp2&waypoint_targets=wpt1%3Bwpt2&waypoints_per_route=true`)
 }
+
+func TestDirectionsBearingsRadiusesLayers(t *testing.T) {
+	coordinates := Coordinates{
+		Coordinate{Lat: 33.122508, Lng: -117.306786},
+		Coordinate{Lat: 32.9, Lng: -117.2},
+		Coordinate{Lat: 32.733810, Lng: -117.193443},
+	}
+
+	checkforwardDirectionsRequestURL(t, &DirectionsRequest{
+		Profile:     ProfileDriving,
+		Coordinates: coordinates,
+		Bearings:    Bearings{NewBearing(45, 90), nil, NewBearing(270, 15)},
+		Radiuses:    Radiuses{RadiusUnlimited, RadiusDefault, 25.5},
+		Layers:      Layers{NewLayer(0), nil, NewLayer(-1)},
+	}, `/directions/v5/mapbox/driving/-117.306786,33.122508;-117.2,32.9;-117.193443,32.73381?bearings=45%2C90%3B%3B270%2C15&layers=0%3B%3B-1&radiuses=unlimited%3B%3B25.5`)
+
+	for _, req := range []*DirectionsRequest{
+		{Profile: ProfileDriving, Coordinates: coordinates, Bearings: Bearings{NewBearing(45, 90)}},
+		{Profile: ProfileDriving, Coordinates: coordinates, Bearings: Bearings{nil, NewBearing(400, 90), nil}},
+		{Profile: ProfileDriving, Coordinates: coordinates, Radiuses: Radiuses{1, -5, 1}},
+		{Profile: ProfileDriving, Coordinates: coordinates, Layers: Layers{nil, nil, NewLayer(200)}},
+	} {
+		client, _ := mockClient()
+		if _, err := client.Directions(context.Background(), req); err == nil || strings.Contains(err.Error(), "mockClient") {
+			t.Errorf("expected a validation error, got %v", err)
+		}
+	}
+}
+
+func TestDirectionsRequestSetStops(t *testing.T) {
+	req := &DirectionsRequest{Profile: ProfileDriving}
+	err := req.SetStops([]DirectionsStop{
+		{Coordinate: Coordinate{Lat: 33.122508, Lng: -117.306786}, Name: "depot", Bearing: NewBearing(90, 45)},
+		{Coordinate: Coordinate{Lat: 32.9, Lng: -117.2}, Via: true, Radius: RadiusUnlimited},
+		{Coordinate: Coordinate{Lat: 32.733810, Lng: -117.193443}, Approach: ApproachCurb, Target: Coordinate{Lat: 32.7337, Lng: -117.1933}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkforwardDirectionsRequestURL(t, req, `/directions/v5/mapbox/driving/-117.306786,33.122508;-117.2,32.9;-117.193443,32.73381?approaches=%3B%3Bcurb&bearings=90%2C45%3B%3B&radiuses=%3Bunlimited%3B&steps=true&waypoint_names=depot%3B&waypoint_targets=%3B%3B-117.1933%2C32.7337&waypoints=0%3B2`)
+
+	if req.Layers != nil {
+		t.Errorf("expected no layers, got %v", req.Layers)
+	}
+
+	for _, stops := range [][]DirectionsStop{
+		{{Coordinate: Coordinate{Lat: 33.1, Lng: -117.3}}},
+		{{Coordinate: Coordinate{Lat: 33.1, Lng: -117.3}, Via: true}, {Coordinate: Coordinate{Lat: 32.7, Lng: -117.1}}},
+		{{Coordinate: Coordinate{Lat: 33.1, Lng: -117.3}}, {Coordinate: Coordinate{Lat: 32.9, Lng: -117.2}, Via: true, Name: "via"}, {Coordinate: Coordinate{Lat: 32.7, Lng: -117.1}}},
+	} {
+		if err := req.SetStops(stops); err == nil {
+			t.Errorf("expected an error for %v", stops)
+		}
+	}
+}
+
+func TestDirectionsPOSTForLongRequests(t *testing.T) {
+	trueVal := true
+
+	req := &DirectionsRequest{Profile: ProfileDriving, Steps: &trueVal}
+	for i := 0; i < 25; i++ {
+		req.Coordinates = append(req.Coordinates, Coordinate{Lat: 32.7 + float64(i)/1000, Lng: -117.1 - float64(i)/1000})
+		req.WaypointNames = append(req.WaypointNames, WaypointName(strings.Repeat(fmt.Sprintf("waypoint %v ", i), 30)))
+	}
+
+	client, requests := mockClient()
+	go client.Directions(context.Background(), req)
+
+	httpReq := <-requests
+	if httpReq.Method != http.MethodPost {
+		t.Fatalf("expected a POST, got %v", httpReq.Method)
+	}
+	if httpReq.URL.RequestURI() != "/directions/v5/mapbox/driving" {
+		t.Errorf("unexpected URL %v", httpReq.URL.RequestURI())
+	}
+	if contentType := httpReq.Header.Get("Content-Type"); contentType != "application/x-www-form-urlencoded" {
+		t.Errorf("unexpected content type %v", contentType)
+	}
+
+	body, err := httpReq.GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form, err := url.ParseQuery(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if form.Get("coordinates") != req.Coordinates.WGS84Format() {
+		t.Errorf("unexpected coordinates %v", form.Get("coordinates"))
+	}
+	if form.Get("waypoint_names") != req.WaypointNames.query() {
+		t.Errorf("unexpected waypoint names %v", form.Get("waypoint_names"))
+	}
+	if form.Get("steps") != "true" {
+		t.Errorf("unexpected steps %v", form.Get("steps"))
+	}
+}
+
+func TestDirectionsRequestTargetFeature(t *testing.T) {
+	feature := &Feature{ID: "warehouse", Properties: &Properties{Coordinates: ExtendedCoordinate{
+		Latitude:  32.7338,
+		Longitude: -117.1934,
+		RoutablePoints: []RoutablePoint{
+			{Name: "loading_dock", Latitude: 32.7330, Longitude: -117.1940},
+			{Name: RoutablePointDefault, Latitude: 32.7340, Longitude: -117.1930},
+		},
+	}}}
+
+	if point, ok := feature.RoutablePoint(RoutablePointOptions{}); !ok || point != (Coordinate{Lat: 32.7340, Lng: -117.1930}) {
+		t.Errorf("expected the default routable point, got %v", point)
+	}
+	if point, _ := feature.RoutablePoint(RoutablePointOptions{Name: "loading_dock"}); point != (Coordinate{Lat: 32.7330, Lng: -117.1940}) {
+		t.Errorf("expected the named routable point, got %v", point)
+	}
+	if point, _ := feature.RoutablePoint(RoutablePointOptions{Origin: Coordinate{Lat: 32.70, Lng: -117.20}}); point != (Coordinate{Lat: 32.7330, Lng: -117.1940}) {
+		t.Errorf("expected the routable point nearest to the origin, got %v", point)
+	}
+
+	req := &DirectionsRequest{
+		Profile: ProfileDriving,
+		Coordinates: Coordinates{
+			Coordinate{Lat: 33.122508, Lng: -117.306786},
+			Coordinate{},
+		},
+	}
+	if err := req.TargetFeature(1, feature, RoutablePointOptions{Approach: ApproachCurb}); err != nil {
+		t.Fatal(err)
+	}
+	if err := req.TargetFeature(2, feature, RoutablePointOptions{}); err == nil {
+		t.Error("expected an error for an out of range waypoint")
+	}
+
+	checkforwardDirectionsRequestURL(t, req, `/directions/v5/mapbox/driving/-117.306786,33.122508;-117.1934,32.7338?approaches=%3Bcurb&steps=true&waypoint_targets=%3B-117.193%2C32.734`)
+}
+
+func TestDirectionsRouteGeometry(t *testing.T) {
+	expected := Coordinates{{Lat: 38.5, Lng: -120.2}, {Lat: 40.7, Lng: -120.95}}
+
+	polyline5, err := EncodePolyline5(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	polyline6, err := EncodePolyline6(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for geometries, geometry := range map[Geometries]string{
+		GeometriesGeoJSON:   `{"type":"LineString","coordinates":[[-120.2,38.5],[-120.95,40.7]]}`,
+		GeometriesPolyline:  `"` + polyline5 + `"`,
+		GeometriesPolyline6: `"` + polyline6 + `"`,
+	} {
+		body := `{"code":"Ok","routes":[{"geometry":` + geometry + `,"legs":[{"steps":[{"geometry":` + geometry + `}]}]}]}`
+		client, requests := mockClient(&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		})
+		go func() { <-requests }()
+
+		resp, err := client.Directions(context.Background(), &DirectionsRequest{
+			Profile:     ProfileDriving,
+			Coordinates: expected,
+			Geometries:  geometries,
+		})
+		if err != nil {
+			t.Fatalf("%v: %v", geometries, err)
+		}
+
+		route := resp.Routes[0]
+		for _, g := range []RouteGeometry{route.Geometry, route.Legs[0].Steps[0].Geometry} {
+			if g.Format != geometries {
+				t.Errorf("expected format %v, got %v", geometries, g.Format)
+			}
+
+			coordinates, err := g.Coordinates()
+			if err != nil {
+				t.Fatalf("%v: %v", geometries, err)
+			}
+			if !reflect.DeepEqual(coordinates, expected) {
+				t.Errorf("%v: expected %v, got %v", geometries, expected, coordinates)
+			}
+		}
+
+		// the polyline precision is lost when the route is stored and decoded again
+		b, err := json.Marshal(route)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stored Route
+		if err := json.Unmarshal(b, &stored); err != nil {
+			t.Fatal(err)
+		}
+		if geometries == GeometriesPolyline6 {
+			if stored.Geometry.Format != GeometriesPolyline {
+				t.Errorf("expected stored polyline6 geometry to default to polyline, got %v", stored.Geometry.Format)
+			}
+			stored.Geometry.Format = GeometriesPolyline6
+		}
+		if coordinates, err := stored.Geometry.Coordinates(); err != nil || !reflect.DeepEqual(coordinates, expected) {
+			t.Errorf("%v: expected %v after a round trip, got %v, %v", geometries, expected, coordinates, err)
+		}
+	}
+}
+
+func TestDirectionsAnnotations(t *testing.T) {
+	body := `{"code":"Ok","routes":[{"legs":[{
+		"annotation":{
+			"maxspeed":[{"speed":50,"unit":"km/h"},{"speed":30,"unit":"mph"},{"unknown":true},{"none":true}],
+			"congestion_numeric":[10,null,90,0],
+			"state_of_charge":[80,79,78,77]
+		},
+		"closures":[{"geometry_index_start":1,"geometry_index_end":3}],
+		"incidents":[{"id":"1234","type":"construction","impact":"minor","congestion":{"value":40},"closed":true,"geometry_index_start":1,"geometry_index_end":3}]
+	}]}]}`
+	client, requests := mockClient(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	})
+	go func() {
+		httpReq := <-requests
+		if annotations := httpReq.URL.Query().Get("annotations"); annotations != "maxspeed,congestion_numeric,closure,state_of_charge" {
+			t.Errorf("unexpected annotations %v", annotations)
+		}
+	}()
+
+	resp, err := client.Directions(context.Background(), &DirectionsRequest{
+		Profile:     ProfileDrivingTraffic,
+		Coordinates: Coordinates{{Lat: 33.122508, Lng: -117.306786}, {Lat: 32.733810, Lng: -117.193443}},
+		Annotations: Annotations{AnnotationMaxspeed, AnnotationCongestionNumeric, AnnotationClosure, AnnotationStateOfCharge},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leg := resp.Routes[0].Legs[0]
+
+	var kph []float64
+	for _, maxspeed := range leg.Annotation.Maxspeed {
+		if speed, ok := maxspeed.KPH(); ok {
+			kph = append(kph, math.Round(speed))
+		}
+	}
+	if !reflect.DeepEqual(kph, []float64{50, 48}) {
+		t.Errorf("unexpected speed limits %v", kph)
+	}
+	if !leg.Annotation.Maxspeed[2].Unknown || !leg.Annotation.Maxspeed[3].None {
+		t.Errorf("expected unknown and no speed limits, got %+v", leg.Annotation.Maxspeed)
+	}
+
+	if congestion := leg.Annotation.CongestionNumeric; len(congestion) != 4 || congestion[1] != nil || *congestion[2] != 90 {
+		t.Errorf("unexpected congestion %v", congestion)
+	}
+	if len(leg.Annotation.StateOfCharge) != 4 {
+		t.Errorf("unexpected state of charge %v", leg.Annotation.StateOfCharge)
+	}
+
+	if !reflect.DeepEqual(leg.Closures, []Closure{{GeometryIndexStart: 1, GeometryIndexEnd: 3}}) {
+		t.Errorf("unexpected closures %v", leg.Closures)
+	}
+	if len(leg.Incidents) != 1 || leg.Incidents[0].Congestion.Value != 40 || !leg.Incidents[0].Closed {
+		t.Errorf("unexpected incidents %+v", leg.Incidents)
+	}
+}
+
+func TestDirectionsElectricVehicle(t *testing.T) {
+	coordinates := Coordinates{{Lat: 33.122508, Lng: -117.306786}, {Lat: 36.1699, Lng: -115.1398}}
+
+	checkforwardDirectionsRequestURL(t, &DirectionsRequest{
+		Profile:     ProfileDriving,
+		Coordinates: coordinates,
+		EV: &DirectionsEV{
+			InitialCharge:          30000,
+			MaxCharge:              60000,
+			ConnectorTypes:         ConnectorTypes{ConnectorCCSComboType1, ConnectorTesla},
+			EnergyConsumptionCurve: EnergyConsumptionCurve{{Speed: 0, Consumption: 300}, {Speed: 100, Consumption: 180.5}},
+			ChargingCurve:          ChargingCurve{{Charge: 0, Power: 100000}, {Charge: 60000, Power: 50000}},
+			MinChargeAtDestination: 6000,
+			AuxiliaryConsumption:   300,
+		},
+	}, `/directions/v5/mapbox/driving/-117.306786,33.122508;-115.1398,36.1699?auxiliary_consumption=300&energy_consumption_curve=0%2C300%3B100%2C180.5&engine=electric&ev_charging_curve=0%2C100000%3B60000%2C50000&ev_connector_types=ccs_combo_type1%2Ctesla&ev_initial_charge=30000&ev_max_charge=60000&ev_min_charge_at_destination=6000`)
+
+	valid := DirectionsEV{
+		InitialCharge:          30000,
+		MaxCharge:              60000,
+		ConnectorTypes:         ConnectorTypes{ConnectorCCSComboType1},
+		EnergyConsumptionCurve: EnergyConsumptionCurve{{Speed: 0, Consumption: 300}, {Speed: 100, Consumption: 180.5}},
+		ChargingCurve:          ChargingCurve{{Charge: 0, Power: 100000}, {Charge: 60000, Power: 50000}},
+	}
+	for name, invalid := range map[string]func(ev *DirectionsEV){
+		"missing initial charge":            func(ev *DirectionsEV) { ev.InitialCharge = 0 },
+		"missing max charge":                func(ev *DirectionsEV) { ev.MaxCharge = 0 },
+		"missing connector types":           func(ev *DirectionsEV) { ev.ConnectorTypes = nil },
+		"missing energy consumption curve":  func(ev *DirectionsEV) { ev.EnergyConsumptionCurve = nil },
+		"missing charging curve":            func(ev *DirectionsEV) { ev.ChargingCurve = nil },
+		"initial charge exceeds max charge": func(ev *DirectionsEV) { ev.InitialCharge = 70000 },
+		"decreasing charging curve":         func(ev *DirectionsEV) { ev.ChargingCurve = ChargingCurve{{Charge: 1000}, {Charge: 0}} },
+	} {
+		ev := valid
+		invalid(&ev)
+
+		client, _ := mockClient()
+		if _, err := client.Directions(context.Background(), &DirectionsRequest{
+			Profile:     ProfileDriving,
+			Coordinates: coordinates,
+			EV:          &ev,
+		}); err == nil || strings.Contains(err.Error(), "mockClient") {
+			t.Errorf("%v: expected a validation error, got %v", name, err)
+		}
+	}
+
+	client, _ := mockClient()
+	if _, err := client.Directions(context.Background(), &DirectionsRequest{
+		Profile:     ProfileWalking,
+		Coordinates: coordinates,
+		EV:          &valid,
+	}); err == nil || strings.Contains(err.Error(), "mockClient") {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+
+	var resp DirectionsResponse
+	if err := json.Unmarshal([]byte(`{"code":"Ok","routes":[],"waypoints":[
+		{"name":"","location":[-117.306786,33.122508]},
+		{"name":"Barstow","location":[-117.03,34.89],"metadata":{"type":"charging-station","name":"Supercharger","charge_time":1292,"charge_to":45000,"charge_at_arrival":6032,"plug_type":"tesla","power_kw":150,"station_id":"ocm-1234"}},
+		{"name":"","location":[-115.1398,36.1699]}
+	]}`), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	stations := resp.ChargingStations()
+	if len(stations) != 1 || stations[0].Metadata.ChargeTo != 45000 || stations[0].Metadata.StationID != "ocm-1234" {
+		t.Errorf("unexpected charging stations %+v", stations)
+	}
+}
+
+func TestRouteLegLocateIncidents(t *testing.T) {
+	var leg RouteLeg
+	if err := json.Unmarshal([]byte(`{
+		"steps":[
+			{"distance":100,"geometry":{"type":"LineString","coordinates":[[-117.30,33.12],[-117.29,33.11],[-117.28,33.10]]}},
+			{"distance":200,"geometry":{"type":"LineString","coordinates":[[-117.28,33.10],[-117.27,33.09],[-117.26,33.08]]}},
+			{"distance":0,"geometry":{"type":"LineString","coordinates":[[-117.26,33.08],[-117.26,33.08]]}}
+		],
+		"incidents":[{"id":"1","type":"accident","geometry_index_start":1,"geometry_index_end":3}],
+		"closures":[{"geometry_index_start":3,"geometry_index_end":4}],
+		"notifications":[
+			{"type":"violation","subtype":"maxHeight","geometry_index_start":2,"geometry_index_end":4,"details":{"requested_value":4.5,"actual_value":4.1,"unit":"meters"}},
+			{"type":"alert","subtype":"evInsufficientCharge"}
+		]
+	}`), &leg); err != nil {
+		t.Fatal(err)
+	}
+
+	spans, err := leg.StepSpans()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(spans, []GeometrySpan{{0, 2}, {2, 4}, {4, 4}}) {
+		t.Errorf("unexpected step spans %v", spans)
+	}
+
+	incidents, err := leg.LocateIncidents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incidents) != 1 || !reflect.DeepEqual(incidents[0].Steps, []int{0, 1}) || len(incidents[0].Coordinates) != 3 {
+		t.Errorf("unexpected incident location %+v", incidents)
+	}
+
+	closure, err := leg.Locate(leg.Closures[0].Span())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(closure.Steps, []int{1, 2}) || closure.Coordinates[1] != (Coordinate{Lat: 33.08, Lng: -117.26}) {
+		t.Errorf("unexpected closure location %+v", closure)
+	}
+
+	closures, err := leg.LocateClosures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(closures) != 1 || !reflect.DeepEqual(closures[0].LegLocation, closure) {
+		t.Errorf("unexpected closure locations %+v", closures)
+	}
+
+	notifications, err := leg.LocateNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 2 || !reflect.DeepEqual(notifications[0].Steps, []int{0, 1, 2}) || len(notifications[0].Coordinates) != 3 {
+		t.Errorf("unexpected notification location %+v", notifications)
+	}
+	if notifications[1].Notification.Subtype != "evInsufficientCharge" || notifications[1].Span != (GeometrySpan{0, 4}) || len(notifications[1].Steps) != 3 {
+		t.Errorf("expected the whole leg notification to cover the leg, got %+v", notifications[1])
+	}
+
+	if span, ok := leg.Notifications[0].Span(); !ok || span != (GeometrySpan{2, 4}) {
+		t.Errorf("unexpected notification span %v", span)
+	}
+	if _, ok := leg.Notifications[1].Span(); ok {
+		t.Errorf("expected the whole leg notification to have no span")
+	}
+
+	if _, err := leg.Locate(GeometrySpan{3, 9}); err == nil {
+		t.Errorf("expected an out of range error")
+	}
+}
+
+func TestRouteLegStepSpansZeroDistanceStep(t *testing.T) {
+	var leg RouteLeg
+	if err := json.Unmarshal([]byte(`{
+		"steps":[
+			{"distance":100,"geometry":{"type":"LineString","coordinates":[[-117.30,33.12],[-117.29,33.11]]}},
+			{"distance":0,"geometry":{"type":"LineString","coordinates":[[-117.29,33.11],[-117.28999,33.10999]]}},
+			{"distance":200,"geometry":{"type":"LineString","coordinates":[[-117.28999,33.10999],[-117.28,33.10]]}},
+			{"distance":0,"geometry":{"type":"LineString","coordinates":[[-117.28,33.10],[-117.28,33.10]]}}
+		]
+	}`), &leg); err != nil {
+		t.Fatal(err)
+	}
+
+	spans, err := leg.StepSpans()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(spans, []GeometrySpan{{0, 1}, {1, 2}, {2, 3}, {3, 3}}) {
+		t.Errorf("unexpected step spans %v", spans)
+	}
+
+	coordinates, err := leg.Coordinates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coordinates) != 4 || coordinates[2] != (Coordinate{Lat: 33.10999, Lng: -117.28999}) {
+		t.Errorf("unexpected leg coordinates %v", coordinates)
+	}
+}
+
+func TestStepIntersectionsAndManeuver(t *testing.T) {
+	var step Step
+	if err := json.Unmarshal([]byte(`{
+		"maneuver":{"type":"roundabout","modifier":"slight right","exit":2,"location":[-117.3,33.1]},
+		"intersections":[{
+			"location":[-117.3,33.1],"bearings":[0,90,180],"entry":[true,true,false],"in":2,"out":1,
+			"classes":["toll","motorway"],
+			"lanes":[{"valid":false,"active":false,"indications":["left"]},{"valid":true,"active":true,"valid_indication":"straight","indications":["straight","right"]}],
+			"geometry_index":4,"is_urban":true,"admin_index":0,
+			"toll_collection":{"type":"toll_gantry"},"rest_stop":{"type":"service_area","name":"Oasis"},
+			"traffic_signal":true,"mapbox_streets_v8":{"class":"motorway"}
+		}]
+	}`), &step); err != nil {
+		t.Fatal(err)
+	}
+
+	if step.Maneuver.Type != ManeuverTypeRoundabout || step.Maneuver.Modifier != ManeuverModifierSlightRight || step.Maneuver.Exit != 2 {
+		t.Errorf("unexpected maneuver %+v", step.Maneuver)
+	}
+
+	intersection := step.Intersections[0]
+	if !intersection.HasClass(RoadClassToll) || intersection.HasClass(RoadClassFerry) {
+		t.Errorf("unexpected classes %v", intersection.Classes)
+	}
+	if len(intersection.Lanes) != 2 || !intersection.Lanes[1].Active || intersection.Lanes[1].ValidIndication != LaneIndicationStraight {
+		t.Errorf("unexpected lanes %+v", intersection.Lanes)
+	}
+	if *intersection.GeometryIndex != 4 || !*intersection.IsUrban || *intersection.AdminIndex != 0 {
+		t.Errorf("unexpected intersection %+v", intersection)
+	}
+	if intersection.TollCollection.Type != TollCollectionTypeGantry || intersection.RestStop.Name != "Oasis" || !intersection.TrafficSignal {
+		t.Errorf("unexpected intersection %+v", intersection)
+	}
+	if intersection.MapboxStreetsV8.Class != "motorway" {
+		t.Errorf("unexpected road class %v", intersection.MapboxStreetsV8)
+	}
+}
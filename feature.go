@@ -0,0 +1,170 @@
+package mapbox
+
+// CommonFeature holds the fields shared by geocoding and Search Box features, so both can
+// be handled by the same code.
+type CommonFeature struct {
+	ID             string
+	MapboxID       string
+	FeatureType    Type
+	Name           string
+	FullAddress    string
+	Coordinate     Coordinate
+	RoutablePoints []RoutablePoint
+	Context        FeatureContext
+	BoundingBox    []float64 // [minLng, minLat, maxLng, maxLat]
+}
+
+// AnyFeature is implemented by *Feature and *SearchboxReverseFeature.
+type AnyFeature interface {
+	Common() CommonFeature
+}
+
+func (f *Feature) Common() CommonFeature {
+	if f == nil {
+		return CommonFeature{}
+	}
+
+	c := CommonFeature{ID: f.ID}
+	if p := f.Properties; p != nil {
+		c.MapboxID = p.MapboxID
+		c.FeatureType = p.FeatureType
+		c.Name = p.Name
+		c.FullAddress = p.FullAddress
+		c.RoutablePoints = p.Coordinates.RoutablePoints
+		c.Context = p.Context
+		c.BoundingBox = p.BoundingBox
+		c.Coordinate = featureCoordinate(p.Coordinates, f.Geometry)
+	} else {
+		c.Coordinate = featureCoordinate(ExtendedCoordinate{}, f.Geometry)
+	}
+
+	return c
+}
+
+func (f *SearchboxReverseFeature) Common() CommonFeature {
+	if f == nil {
+		return CommonFeature{}
+	}
+
+	c := CommonFeature{ID: f.ID}
+	if p := f.Properties; p != nil {
+		c.MapboxID = p.MapboxID
+		c.FeatureType = p.FeatureType
+		c.Name = p.Name
+		c.FullAddress = p.FullAddress
+		c.RoutablePoints = p.Coordinates.RoutablePoints
+		c.Context = p.Context
+		c.BoundingBox = p.BoundingBox
+		c.Coordinate = featureCoordinate(p.Coordinates, f.Geometry)
+	} else {
+		c.Coordinate = featureCoordinate(ExtendedCoordinate{}, f.Geometry)
+	}
+
+	return c
+}
+
+func (r *GeocodeResponse) CommonFeatures() []CommonFeature {
+	res := make([]CommonFeature, 0, len(r.Features))
+
+	for _, feature := range r.Features {
+		res = append(res, feature.Common())
+	}
+
+	return res
+}
+
+func (r *SearchboxReverseResponse) CommonFeatures() []CommonFeature {
+	return searchboxCommonFeatures(r.Features)
+}
+
+func (r *SearchboxForwardResponse) CommonFeatures() []CommonFeature {
+	return searchboxCommonFeatures(r.Features)
+}
+
+func (r *SearchboxRetrieveResponse) CommonFeatures() []CommonFeature {
+	return searchboxCommonFeatures(r.Features)
+}
+
+func (r *SearchboxCategoryResponse) CommonFeatures() []CommonFeature {
+	return searchboxCommonFeatures(r.Features)
+}
+
+func searchboxCommonFeatures(features []*SearchboxReverseFeature) []CommonFeature {
+	res := make([]CommonFeature, 0, len(features))
+
+	for _, feature := range features {
+		res = append(res, feature.Common())
+	}
+
+	return res
+}
+
+// featureCoordinate prefers the precise coordinates from the properties over the geometry.
+func featureCoordinate(c ExtendedCoordinate, g *Geometry) Coordinate {
+	if c.Latitude != 0 || c.Longitude != 0 {
+		return Coordinate{Lat: c.Latitude, Lng: c.Longitude}
+	}
+	if g != nil && len(g.Coordinates) >= 2 {
+		return Coordinate{Lat: g.Latitude(), Lng: g.Longitude()}
+	}
+	return Coordinate{}
+}
+
+//////////////////////////////////////////////////////////////////
+
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+type GeoJSONFeature struct {
+	Type        string                 `json:"type"`
+	ID          string                 `json:"id,omitempty"`
+	Geometry    *Geometry              `json:"geometry"`
+	BoundingBox []float64              `json:"bbox,omitempty"`
+	Properties  map[string]interface{} `json:"properties"`
+}
+
+// GeoJSON converts the feature into a GeoJSON Point feature.
+func (c CommonFeature) GeoJSON() GeoJSONFeature {
+	properties := map[string]interface{}{}
+	for key, value := range map[string]string{
+		"mapbox_id":    c.MapboxID,
+		"feature_type": string(c.FeatureType),
+		"name":         c.Name,
+		"full_address": c.FullAddress,
+	} {
+		if value != "" {
+			properties[key] = value
+		}
+	}
+	if len(c.RoutablePoints) > 0 {
+		properties["routable_points"] = c.RoutablePoints
+	}
+	if len(c.Context) > 0 {
+		properties["context"] = c.Context
+	}
+
+	feature := GeoJSONFeature{
+		Type:        "Feature",
+		ID:          c.ID,
+		BoundingBox: c.BoundingBox,
+		Properties:  properties,
+	}
+	if !c.Coordinate.IsZero() {
+		feature.Geometry = &Geometry{Type: "Point", Coordinates: []float64{c.Coordinate.Lng, c.Coordinate.Lat}}
+	}
+
+	return feature
+}
+
+// NewGeoJSONFeatureCollection converts the features into a GeoJSON FeatureCollection.
+func NewGeoJSONFeatureCollection(features ...CommonFeature) GeoJSONFeatureCollection {
+	collection := GeoJSONFeatureCollection{Type: "FeatureCollection", Features: make([]GeoJSONFeature, 0, len(features))}
+
+	for _, feature := range features {
+		collection.Features = append(collection.Features, feature.GeoJSON())
+	}
+
+	return collection
+}
@@ -0,0 +1,50 @@
+package mapbox
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCommonFeature(t *testing.T) {
+	var geocoded GeocodeResponse
+	err := json.Unmarshal([]byte(`{"features":[{"id":"g1","geometry":{"type":"Point","coordinates":[-117.3,33.1]},
+		"properties":{"mapbox_id":"a","feature_type":"address","name":"6005 Hidden Valley Road",
+			"coordinates":{"latitude":33.12,"longitude":-117.31,"routable_points":[{"name":"default","latitude":33.121,"longitude":-117.311}]},
+			"context":{"place":{"mapbox_id":"p","name":"Carlsbad"}},"bbox":[-117.4,33.0,-117.2,33.2]}}]}`), &geocoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var searched SearchboxReverseResponse
+	err = json.Unmarshal([]byte(`{"features":[{"id":"s1","geometry":{"type":"Point","coordinates":[-117.3,33.1]},
+		"properties":{"mapbox_id":"b","feature_type":"poi","name":"Palomar Airport"}}]}`), &searched)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var features []AnyFeature
+	features = append(features, geocoded.Features[0], searched.Features[0])
+
+	address := features[0].Common()
+	if address.MapboxID != "a" || address.Coordinate != (Coordinate{Lat: 33.12, Lng: -117.31}) ||
+		len(address.RoutablePoints) != 1 || address.Context.Place().Name != "Carlsbad" || len(address.BoundingBox) != 4 {
+		t.Errorf("unexpected geocoding feature %+v", address)
+	}
+
+	poi := features[1].Common()
+	if poi.MapboxID != "b" || poi.FeatureType != TypePOI || poi.Coordinate != (Coordinate{Lat: 33.1, Lng: -117.3}) {
+		t.Errorf("expected the geometry to stand in for missing coordinates, got %+v", poi)
+	}
+
+	b, err := json.Marshal(NewGeoJSONFeatureCollection(searched.CommonFeatures()...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"type":"FeatureCollection","features":[{"type":"Feature","id":"s1",` +
+		`"geometry":{"coordinates":[-117.3,33.1],"type":"Point"},` +
+		`"properties":{"feature_type":"poi","mapbox_id":"b","name":"Palomar Airport"}}]}`
+	if string(b) != expected {
+		t.Errorf("expected:\n%s, got:\n%s", expected, b)
+	}
+}
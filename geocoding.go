@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -94,7 +95,88 @@ type GeocodeResponse struct {
 }
 
 type GeocodeBatchResponse struct {
-	Batch []GeocodeResponse `json:"batch"`
+	Batch []GeocodeBatchResult `json:"batch"`
+}
+
+// Indexes returns the positions in the batch, i.e. in the original request, of the
+// results with the given status.
+func (r *GeocodeBatchResponse) Indexes(status GeocodeBatchStatus) []int {
+	var indexes []int
+	for i := range r.Batch {
+		if r.Batch[i].Status == status {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// partial reports whether any of the batch results are usable, letting a batch
+// succeed even when the API flags part of it as invalid.
+func (r *GeocodeBatchResponse) partial() bool {
+	for i := range r.Batch {
+		if r.Batch[i].Status != GeocodeBatchStatusInvalid {
+			return true
+		}
+	}
+	return false
+}
+
+// GeocodeBatchStatus is the outcome of a single query within a batch request.
+type GeocodeBatchStatus string
+
+const (
+	GeocodeBatchStatusOK       GeocodeBatchStatus = "ok"
+	GeocodeBatchStatusNoResult GeocodeBatchStatus = "no_result"
+	GeocodeBatchStatusInvalid  GeocodeBatchStatus = "invalid"
+)
+
+// GeocodeBatchResult is the response to a single query within a batch request.
+// Message is only set when Status is GeocodeBatchStatusInvalid.
+type GeocodeBatchResult struct {
+	GeocodeResponse
+	Status  GeocodeBatchStatus `json:"status"`
+	Message string             `json:"message,omitempty"`
+}
+
+func (r *GeocodeBatchResult) UnmarshalJSON(b []byte) error {
+	type geocodeBatchResult struct {
+		GeocodeResponse
+		Message string `json:"message"`
+		Error   string `json:"error"`
+	}
+
+	var res geocodeBatchResult
+	if err := json.Unmarshal(b, &res); err != nil {
+		return err
+	}
+
+	*r = GeocodeBatchResult{GeocodeResponse: res.GeocodeResponse}
+
+	switch {
+	case res.Message != "" || res.Error != "":
+		r.Status = GeocodeBatchStatusInvalid
+		r.Message = res.Message
+		if r.Message == "" {
+			r.Message = res.Error
+		}
+	case len(res.Features) == 0:
+		r.Status = GeocodeBatchStatusNoResult
+	default:
+		r.Status = GeocodeBatchStatusOK
+	}
+
+	return nil
+}
+
+// ErrGeocodeBatchInvalid is wrapped by the errors of invalid batch results.
+var ErrGeocodeBatchInvalid = errors.New("invalid batch query")
+
+// Err returns the reason an invalid query failed, or nil otherwise.
+func (r *GeocodeBatchResult) Err() error {
+	if r.Status != GeocodeBatchStatusInvalid {
+		return nil
+	}
+	return fmt.Errorf("%w: %v", ErrGeocodeBatchInvalid, r.Message)
 }
 
 //////////////////////////////////////////////////////////////////
@@ -114,19 +196,25 @@ type Properties struct {
 	PlaceFormatted string             `json:"place_formatted"`
 	FullAddress    string             `json:"full_address"`
 	Coordinates    ExtendedCoordinate `json:"coordinates"`
-	Context        map[Type]Context   `json:"context,omitempty"`
+	Context        FeatureContext     `json:"context,omitempty"`
 	BoundingBox    []float64          `json:"bbox,omitempty"`
 	MatchCode      *MatchCode         `json:"match_code,omitempty"`
 }
 
 // There are many different types of context objects, which are all mashed together
-// here.
+// here. See FeatureContext for typed access.
 // https://docs.mapbox.com/api/search/geocoding/#the-context-object
 type Context struct {
 	// Always present
 	MapboxID string `json:"mapbox_id"`
 	Name     string `json:"name"`
 
+	// The Search Box API identifies context objects by id rather than mapbox_id
+	ID string `json:"id,omitempty"`
+
+	// Names of the context object in other languages, keyed by language code
+	Translations map[string]ContextTranslation `json:"translations,omitempty"`
+
 	// Optional but shared between many context types
 	WikidataID string `json:"wikidata_id,omitempty"`
 
@@ -242,12 +330,12 @@ func forwardGeocodeBatch(ctx context.Context, client *Client, req ForwardGeocode
 		return nil, err
 	}
 
-	var response *GeocodeBatchResponse
+	var response GeocodeBatchResponse
 	if err := client.handleResponse(apiResponse, &response, GeocodingRateLimit); err != nil {
 		return nil, err
 	}
 
-	return response, nil
+	return &response, nil
 }
 
 // https://docs.mapbox.com/api/search/geocoding/#reverse-geocoding
@@ -301,10 +389,10 @@ func reverseGeocodeBatch(ctx context.Context, client *Client, req ReverseGeocode
 		return nil, err
 	}
 
-	var response *GeocodeBatchResponse
+	var response GeocodeBatchResponse
 	if err := client.handleResponse(apiResponse, &response, GeocodingRateLimit); err != nil {
 		return nil, err
 	}
 
-	return response, nil
+	return &response, nil
 }
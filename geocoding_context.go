@@ -0,0 +1,216 @@
+package mapbox
+
+// FeatureContext is the hierarchy of places a feature belongs to, keyed by the type
+// of each context object. The accessors return nil when the feature has no context
+// object of that type.
+// https://docs.mapbox.com/api/search/geocoding/#the-context-object
+type FeatureContext map[Type]Context
+
+type ContextTranslation struct {
+	Language string `json:"language"`
+	Name     string `json:"name"`
+}
+
+type CountryContext struct {
+	MapboxID          string
+	Name              string
+	WikidataID        string
+	CountryCode       string // ISO 3166-1 alpha-2
+	CountryCodeAlpha3 string // ISO 3166-1 alpha-3
+	Translations      map[string]ContextTranslation
+}
+
+type RegionContext struct {
+	MapboxID       string
+	Name           string
+	WikidataID     string
+	RegionCode     string // e.g. "CA"
+	RegionCodeFull string // ISO 3166-2, e.g. "US-CA"
+	Translations   map[string]ContextTranslation
+}
+
+type PostcodeContext struct {
+	MapboxID     string
+	Name         string
+	Translations map[string]ContextTranslation
+}
+
+type DistrictContext struct {
+	MapboxID     string
+	Name         string
+	WikidataID   string
+	Translations map[string]ContextTranslation
+}
+
+type PlaceContext struct {
+	MapboxID     string
+	Name         string
+	WikidataID   string
+	Translations map[string]ContextTranslation
+}
+
+type LocalityContext struct {
+	MapboxID     string
+	Name         string
+	WikidataID   string
+	Translations map[string]ContextTranslation
+}
+
+type NeighborhoodContext struct {
+	MapboxID     string
+	Name         string
+	Translations map[string]ContextTranslation
+}
+
+type StreetContext struct {
+	MapboxID     string
+	Name         string
+	Translations map[string]ContextTranslation
+}
+
+type AddressContext struct {
+	MapboxID      string
+	Name          string
+	AddressNumber string
+	StreetName    string
+	Translations  map[string]ContextTranslation
+}
+
+//////////////////////////////////////////////////////////////////
+
+// get returns the context object of the given type, normalizing the Search Box id.
+func (f FeatureContext) get(t Type) (Context, bool) {
+	c, ok := f[t]
+	if ok && c.MapboxID == "" {
+		c.MapboxID = c.ID
+	}
+	return c, ok
+}
+
+func (f FeatureContext) Country() *CountryContext {
+	c, ok := f.get(TypeCountry)
+	if !ok {
+		return nil
+	}
+
+	return &CountryContext{
+		MapboxID:          c.MapboxID,
+		Name:              c.Name,
+		WikidataID:        c.WikidataID,
+		CountryCode:       c.CountryCode,
+		CountryCodeAlpha3: c.CountryCodeAlpha3,
+		Translations:      c.Translations,
+	}
+}
+
+func (f FeatureContext) Region() *RegionContext {
+	c, ok := f.get(TypeRegion)
+	if !ok {
+		return nil
+	}
+
+	return &RegionContext{
+		MapboxID:       c.MapboxID,
+		Name:           c.Name,
+		WikidataID:     c.WikidataID,
+		RegionCode:     c.RegionCode,
+		RegionCodeFull: c.RegionCodeFull,
+		Translations:   c.Translations,
+	}
+}
+
+func (f FeatureContext) Postcode() *PostcodeContext {
+	c, ok := f.get(TypePostcode)
+	if !ok {
+		return nil
+	}
+
+	return &PostcodeContext{
+		MapboxID:     c.MapboxID,
+		Name:         c.Name,
+		Translations: c.Translations,
+	}
+}
+
+func (f FeatureContext) District() *DistrictContext {
+	c, ok := f.get(TypeDistrict)
+	if !ok {
+		return nil
+	}
+
+	return &DistrictContext{
+		MapboxID:     c.MapboxID,
+		Name:         c.Name,
+		WikidataID:   c.WikidataID,
+		Translations: c.Translations,
+	}
+}
+
+func (f FeatureContext) Place() *PlaceContext {
+	c, ok := f.get(TypePlace)
+	if !ok {
+		return nil
+	}
+
+	return &PlaceContext{
+		MapboxID:     c.MapboxID,
+		Name:         c.Name,
+		WikidataID:   c.WikidataID,
+		Translations: c.Translations,
+	}
+}
+
+func (f FeatureContext) Locality() *LocalityContext {
+	c, ok := f.get(TypeLocality)
+	if !ok {
+		return nil
+	}
+
+	return &LocalityContext{
+		MapboxID:     c.MapboxID,
+		Name:         c.Name,
+		WikidataID:   c.WikidataID,
+		Translations: c.Translations,
+	}
+}
+
+func (f FeatureContext) Neighborhood() *NeighborhoodContext {
+	c, ok := f.get(TypeNeighborhood)
+	if !ok {
+		return nil
+	}
+
+	return &NeighborhoodContext{
+		MapboxID:     c.MapboxID,
+		Name:         c.Name,
+		Translations: c.Translations,
+	}
+}
+
+func (f FeatureContext) Street() *StreetContext {
+	c, ok := f.get(TypeStreet)
+	if !ok {
+		return nil
+	}
+
+	return &StreetContext{
+		MapboxID:     c.MapboxID,
+		Name:         c.Name,
+		Translations: c.Translations,
+	}
+}
+
+func (f FeatureContext) Address() *AddressContext {
+	c, ok := f.get(TypeAddress)
+	if !ok {
+		return nil
+	}
+
+	return &AddressContext{
+		MapboxID:      c.MapboxID,
+		Name:          c.Name,
+		AddressNumber: c.AddressNumber,
+		StreetName:    c.StreetName,
+		Translations:  c.Translations,
+	}
+}
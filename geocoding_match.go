@@ -0,0 +1,180 @@
+package mapbox
+
+import (
+	"sort"
+)
+
+const (
+	MatchCodeComponentAddressNumber = MatchCodeComponent("address_number")
+	MatchCodeComponentStreet        = MatchCodeComponent("street")
+	MatchCodeComponentPostcode      = MatchCodeComponent("postcode")
+	MatchCodeComponentPlace         = MatchCodeComponent("place")
+	MatchCodeComponentRegion        = MatchCodeComponent("region")
+	MatchCodeComponentLocality      = MatchCodeComponent("locality")
+	MatchCodeComponentCountry       = MatchCodeComponent("country")
+
+	// The feature satisfies the criteria and can be used as is
+	MatchVerdictAccept = MatchVerdict("accept")
+	// The feature satisfies the criteria, but Mapbox inferred or could not verify some of its components
+	MatchVerdictReview = MatchVerdict("review")
+	// The feature does not satisfy the criteria
+	MatchVerdictReject = MatchVerdict("reject")
+)
+
+// MatchCodeComponent is an address component scored in a MatchCode.
+type MatchCodeComponent string
+
+type MatchVerdict string
+
+var matchCodeComponents = []MatchCodeComponent{
+	MatchCodeComponentAddressNumber,
+	MatchCodeComponentStreet,
+	MatchCodeComponentPostcode,
+	MatchCodeComponentPlace,
+	MatchCodeComponentRegion,
+	MatchCodeComponentLocality,
+	MatchCodeComponentCountry,
+}
+
+// Rank orders confidences from 0 (unknown) to 4 (exact).
+func (c MatchCodeConfidence) Rank() int {
+	switch c {
+	case MatchCodeConfidenceExact:
+		return 4
+	case MatchCodeConfidenceHigh:
+		return 3
+	case MatchCodeConfidenceMedium:
+		return 2
+	case MatchCodeConfidenceLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Value returns how well the given component matched the query.
+func (m *MatchCode) Value(component MatchCodeComponent) MatchCodeValue {
+	switch component {
+	case MatchCodeComponentAddressNumber:
+		return m.AddressNumber
+	case MatchCodeComponentStreet:
+		return m.Street
+	case MatchCodeComponentPostcode:
+		return m.Postcode
+	case MatchCodeComponentPlace:
+		return m.Place
+	case MatchCodeComponentRegion:
+		return m.Region
+	case MatchCodeComponentLocality:
+		return m.Locality
+	case MatchCodeComponentCountry:
+		return m.Country
+	default:
+		return ""
+	}
+}
+
+// Components returns the components with the given value, e.g. all inferred components.
+func (m *MatchCode) Components(value MatchCodeValue) []MatchCodeComponent {
+	var res []MatchCodeComponent
+
+	for _, component := range matchCodeComponents {
+		if m.Value(component) == value {
+			res = append(res, component)
+		}
+	}
+
+	return res
+}
+
+//////////////////////////////////////////////////////////////////
+
+// MatchCriteria describes which forward geocoding results are good enough to use.
+// The zero value accepts everything, except ambiguous matches which are flagged for review.
+type MatchCriteria struct {
+	MinConfidence MatchCodeConfidence
+	Required      []MatchCodeComponent // components which must be matched
+}
+
+// MatchResult is a feature scored against MatchCriteria.
+type MatchResult struct {
+	Feature    *Feature
+	Verdict    MatchVerdict
+	Confidence MatchCodeConfidence
+
+	Unmatched []MatchCodeComponent // required components which did not match
+	Inferred  []MatchCodeComponent // components Mapbox added which were not in the query
+	Plausible []MatchCodeComponent // components Mapbox could not verify
+}
+
+// Evaluate scores every feature against the criteria, most confident first.
+// Features of equal confidence keep the order Mapbox returned them in.
+func (r *GeocodeResponse) Evaluate(criteria MatchCriteria) []MatchResult {
+	results := make([]MatchResult, 0, len(r.Features))
+
+	for _, feature := range r.Features {
+		results = append(results, criteria.evaluate(feature))
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Confidence.Rank() > results[j].Confidence.Rank()
+	})
+
+	return results
+}
+
+// Filter returns the features which can be accepted without review, most confident first.
+func (r *GeocodeResponse) Filter(criteria MatchCriteria) []*Feature {
+	var features []*Feature
+
+	for _, result := range r.Evaluate(criteria) {
+		if result.Verdict == MatchVerdictAccept {
+			features = append(features, result.Feature)
+		}
+	}
+
+	return features
+}
+
+func (c MatchCriteria) evaluate(feature *Feature) MatchResult {
+	result := MatchResult{Feature: feature, Verdict: MatchVerdictAccept}
+
+	var matchCode *MatchCode
+	if feature != nil && feature.Properties != nil {
+		matchCode = feature.Properties.MatchCode
+	}
+
+	// only address-level results carry a match code
+	if matchCode == nil {
+		if c.MinConfidence.Rank() > 0 || len(c.Required) > 0 {
+			result.Verdict = MatchVerdictReject
+			result.Unmatched = c.Required
+		}
+		return result
+	}
+
+	result.Confidence = matchCode.Confidence
+	result.Inferred = matchCode.Components(MatchCodeValueInferred)
+	result.Plausible = matchCode.Components(MatchCodeValuePlausible)
+
+	for _, component := range c.Required {
+		switch matchCode.Value(component) {
+		case MatchCodeValueMatched, MatchCodeValueInferred, MatchCodeValuePlausible:
+			// inferred and plausible components are flagged for review below
+		case MatchCodeValueUnmatched, MatchCodeValueNotApplicable:
+			result.Unmatched = append(result.Unmatched, component)
+		default:
+			// missing from the match code
+			result.Unmatched = append(result.Unmatched, component)
+		}
+	}
+
+	switch {
+	case matchCode.Confidence.Rank() < c.MinConfidence.Rank() || len(result.Unmatched) > 0:
+		result.Verdict = MatchVerdictReject
+	case len(result.Inferred) > 0 || len(result.Plausible) > 0:
+		result.Verdict = MatchVerdictReview
+	}
+
+	return result
+}
@@ -0,0 +1,600 @@
+package mapbox
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	GeocodeStreamFormatCSV    = GeocodeStreamFormat("csv")
+	GeocodeStreamFormatNDJSON = GeocodeStreamFormat("ndjson")
+
+	// Mapbox accepts at most 1000 queries per batch request
+	GeocodeStreamMaxChunkSize = 1000
+
+	// Consecutive rate limited attempts of a batch request before giving up
+	DefaultGeocodeStreamMaxRetries = 10
+
+	// Fields appended to every output record
+	GeocodeStreamLatitudeField        = "mapbox_latitude"
+	GeocodeStreamLongitudeField       = "mapbox_longitude"
+	GeocodeStreamFullAddressField     = "mapbox_full_address"
+	GeocodeStreamMatchConfidenceField = "mapbox_match_confidence"
+	GeocodeStreamMapboxIDField        = "mapbox_id"
+	GeocodeStreamStatusField          = "mapbox_status"
+	GeocodeStreamMessageField         = "mapbox_message"
+)
+
+type GeocodeStreamFormat string
+
+// GeocodeStreamColumns maps request fields to CSV header names or NDJSON keys.
+// Empty fields are not read from the input.
+type GeocodeStreamColumns struct {
+	SearchText   string
+	AddressLine1 string
+	Postcode     string
+	Place        string
+	Country      string
+	Latitude     string
+	Longitude    string
+}
+
+// DefaultGeocodeStreamColumns uses the Mapbox parameter names as column names.
+var DefaultGeocodeStreamColumns = GeocodeStreamColumns{
+	SearchText:   "q",
+	AddressLine1: "address_line1",
+	Postcode:     "postcode",
+	Place:        "place",
+	Country:      "country",
+	Latitude:     "latitude",
+	Longitude:    "longitude",
+}
+
+type GeocodeStreamRequest struct {
+	// required
+	Format GeocodeStreamFormat
+
+	// optional
+	Reverse    bool                 // reverse geocode the Latitude/Longitude columns instead of forward geocoding the address
+	Columns    GeocodeStreamColumns // defaults to DefaultGeocodeStreamColumns
+	ChunkSize  int                  // queries per batch request, defaults to GeocodeStreamMaxChunkSize
+	Interval   time.Duration        // minimum time between batch requests
+	MaxRetries int                  // rate limited attempts per batch request, defaults to DefaultGeocodeStreamMaxRetries
+	Checkpoint GeocodeCheckpoint    // resume point for interrupted runs
+
+	// optional, applied to every query
+	Country  string // used when the record has no country of its own
+	Language string
+	Types    Types
+}
+
+// GeocodeCheckpoint persists how far a stream got, so a crashed run can resume where it stopped.
+// When resuming, the output must be opened for appending rather than truncated.
+// The checkpoint is saved after a chunk was written, so output is at-least-once: a crash in
+// between writes that chunk again on resume. Deduplicate on the input if that matters.
+type GeocodeCheckpoint interface {
+	// Load returns the number of input records that were already written to the output.
+	Load() (int, error)
+	// Save records that the first n input records were written to the output.
+	Save(n int) error
+}
+
+// FileCheckpoint is a GeocodeCheckpoint stored as a plain text file at the given path.
+type FileCheckpoint string
+
+func (f FileCheckpoint) Load() (int, error) {
+	b, err := os.ReadFile(filepath.Clean(string(f))) //nolint:gosec // the path is chosen by the caller, not by input data
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid checkpoint %v. %w", string(f), err)
+	}
+	return n, nil
+}
+
+func (f FileCheckpoint) Save(n int) error {
+	// write then rename so a crash never leaves a truncated checkpoint behind
+	tmp := string(f) + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.Itoa(n)), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, string(f))
+}
+
+//////////////////////////////////////////////////////////////////
+
+// GeocodeStream reads records from r, geocodes them in batches and writes each record to w
+// enriched with the best match. Records are written in input order. CSV rows with a different
+// number of fields than the header are reported as invalid, with any extra fields written
+// after the result fields.
+func (c *Client) GeocodeStream(ctx context.Context, req *GeocodeStreamRequest, r io.Reader, w io.Writer) error {
+	columns := req.Columns
+	if columns == (GeocodeStreamColumns{}) {
+		columns = DefaultGeocodeStreamColumns
+	}
+
+	chunkSize := req.ChunkSize
+	if chunkSize <= 0 || chunkSize > GeocodeStreamMaxChunkSize {
+		chunkSize = GeocodeStreamMaxChunkSize
+	}
+
+	var done int
+	if req.Checkpoint != nil {
+		var err error
+		if done, err = req.Checkpoint.Load(); err != nil {
+			return err
+		}
+	}
+
+	var reader streamReader
+	var writer streamWriter
+	switch req.Format {
+	case GeocodeStreamFormatCSV:
+		csvReader, err := newCSVStreamReader(r, columns, req.Reverse)
+		if err != nil {
+			return err
+		}
+		reader = csvReader
+		writer = newCSVStreamWriter(w, csvReader.header, done == 0)
+	case GeocodeStreamFormatNDJSON:
+		reader = newNDJSONStreamReader(r, columns, req.Reverse)
+		writer = newNDJSONStreamWriter(w)
+	default:
+		return fmt.Errorf("unsupported geocode stream format %q", req.Format)
+	}
+
+	// skip what a previous run already wrote
+	for i := 0; i < done; i++ {
+		if _, err := reader.next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	var lastRequest time.Time
+	for {
+		chunk := make([]*streamRecord, 0, chunkSize)
+		var eof bool
+		for len(chunk) < chunkSize {
+			record, err := reader.next()
+			if errors.Is(err, io.EOF) {
+				eof = true
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read record %v. %w", done+len(chunk)+1, err)
+			}
+			chunk = append(chunk, record)
+		}
+
+		if len(chunk) > 0 {
+			if err := sleepContext(ctx, req.Interval-time.Since(lastRequest)); err != nil {
+				return err
+			}
+			lastRequest = time.Now()
+
+			results, err := c.geocodeStreamChunk(ctx, req, chunk)
+			if err != nil {
+				return err
+			}
+
+			for i, record := range chunk {
+				if err := writer.write(record, results[i]); err != nil {
+					return err
+				}
+			}
+			if err := writer.flush(); err != nil {
+				return err
+			}
+
+			done += len(chunk)
+			if req.Checkpoint != nil {
+				if err := req.Checkpoint.Save(done); err != nil {
+					return err
+				}
+			}
+		}
+
+		if eof {
+			return writer.flush()
+		}
+	}
+}
+
+// geocodeStreamChunk geocodes the valid records of the chunk in a single batch request,
+// waiting out the rate limit if Mapbox asks us to.
+func (c *Client) geocodeStreamChunk(ctx context.Context, req *GeocodeStreamRequest, chunk []*streamRecord) ([]streamResult, error) {
+	results := make([]streamResult, len(chunk))
+
+	var indexes []int
+	var forward ForwardGeocodeBatchRequest
+	var reverse ReverseGeocodeBatchRequest
+	for i, record := range chunk {
+		if record.invalid != "" {
+			results[i] = streamResult{Status: GeocodeBatchStatusInvalid, Message: record.invalid}
+			continue
+		}
+
+		indexes = append(indexes, i)
+		if req.Reverse {
+			reverse = append(reverse, record.reverseRequest(req))
+		} else {
+			forward = append(forward, record.forwardRequest(req))
+		}
+	}
+
+	if len(indexes) == 0 {
+		return results, nil
+	}
+
+	maxRetries := req.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = DefaultGeocodeStreamMaxRetries
+	}
+
+	for attempt := 1; ; attempt++ {
+		var resp *GeocodeBatchResponse
+		var err error
+		if req.Reverse {
+			resp, err = c.ReverseGeocodeBatch(ctx, reverse)
+		} else {
+			resp, err = c.ForwardGeocodeBatch(ctx, forward)
+		}
+
+		var mapboxErr MapboxError
+		if errors.As(err, &mapboxErr) && mapboxErr.StatusCode == 429 {
+			if attempt > maxRetries {
+				return nil, fmt.Errorf("still rate limited after %v retries. %w", maxRetries, err)
+			}
+			if err := sleepContext(ctx, c.rateLimitWait(GeocodingRateLimit)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resp.Batch) != len(indexes) {
+			return nil, fmt.Errorf("expected %v batch results, got %v", len(indexes), len(resp.Batch))
+		}
+
+		for i, idx := range indexes {
+			results[idx] = newStreamResult(&resp.Batch[i])
+		}
+		return results, nil
+	}
+}
+
+// rateLimitWait returns how long until requests of the given category are allowed again.
+func (c *Client) rateLimitWait(rl RateLimit) time.Duration {
+	wait := time.Until(c.rateLimit(rl))
+	if wait <= 0 {
+		// Mapbox did not tell us when the limit resets
+		wait = time.Second
+	}
+	return wait
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+//////////////////////////////////////////////////////////////////
+
+type streamRecord struct {
+	fields  map[string]string // request field values keyed by GeocodeStreamColumns name
+	invalid string            // reason the record cannot be geocoded
+
+	csv    []string
+	extra  []string // fields of a CSV row beyond the header
+	ndjson map[string]json.RawMessage
+
+	columns GeocodeStreamColumns
+}
+
+func (r *streamRecord) field(column string) string {
+	if column == "" {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[column])
+}
+
+// validate flags records that would be rejected by the API, without spending a query on them.
+func (r *streamRecord) validate(reverse bool) {
+	if !reverse {
+		if r.field(r.columns.SearchText) == "" && r.field(r.columns.AddressLine1) == "" {
+			r.invalid = "missing search text or address"
+		}
+		return
+	}
+
+	for _, column := range []string{r.columns.Latitude, r.columns.Longitude} {
+		if _, err := strconv.ParseFloat(r.field(column), 64); err != nil {
+			r.invalid = fmt.Sprintf("invalid %v %q", column, r.field(column))
+			return
+		}
+	}
+}
+
+func (r *streamRecord) country(req *GeocodeStreamRequest) string {
+	if country := r.field(r.columns.Country); country != "" {
+		return country
+	}
+	return req.Country
+}
+
+func (r *streamRecord) forwardRequest(req *GeocodeStreamRequest) ForwardGeocodeRequest {
+	return ForwardGeocodeRequest{
+		SearchText:   r.field(r.columns.SearchText),
+		AddressLine1: r.field(r.columns.AddressLine1),
+		Postcode:     r.field(r.columns.Postcode),
+		Place:        r.field(r.columns.Place),
+		Country:      r.country(req),
+		Language:     req.Language,
+		Limit:        1,
+		Types:        req.Types,
+	}
+}
+
+func (r *streamRecord) reverseRequest(req *GeocodeStreamRequest) ReverseGeocodeRequest {
+	lat, _ := strconv.ParseFloat(r.field(r.columns.Latitude), 64)
+	lng, _ := strconv.ParseFloat(r.field(r.columns.Longitude), 64)
+
+	return ReverseGeocodeRequest{
+		Coordinate: Coordinate{Lat: lat, Lng: lng},
+		Country:    r.country(req),
+		Language:   req.Language,
+		Limit:      1,
+		Types:      req.Types,
+	}
+}
+
+type streamResult struct {
+	Status          GeocodeBatchStatus
+	Message         string
+	Latitude        string
+	Longitude       string
+	FullAddress     string
+	MatchConfidence MatchCodeConfidence
+	MapboxID        string
+}
+
+func newStreamResult(batchResult *GeocodeBatchResult) streamResult {
+	result := streamResult{Status: batchResult.Status, Message: batchResult.Message}
+	if batchResult.Status != GeocodeBatchStatusOK || batchResult.Features[0].Properties == nil {
+		return result
+	}
+
+	properties := batchResult.Features[0].Properties
+	result.Latitude = strconv.FormatFloat(properties.Coordinates.Latitude, 'f', -1, 64)
+	result.Longitude = strconv.FormatFloat(properties.Coordinates.Longitude, 'f', -1, 64)
+	result.FullAddress = properties.FullAddress
+	result.MapboxID = properties.MapboxID
+	if properties.MatchCode != nil {
+		result.MatchConfidence = properties.MatchCode.Confidence
+	}
+
+	return result
+}
+
+func (r streamResult) values() []string {
+	return []string{
+		r.Latitude,
+		r.Longitude,
+		r.FullAddress,
+		string(r.MatchConfidence),
+		r.MapboxID,
+		string(r.Status),
+		r.Message,
+	}
+}
+
+var streamResultFields = []string{
+	GeocodeStreamLatitudeField,
+	GeocodeStreamLongitudeField,
+	GeocodeStreamFullAddressField,
+	GeocodeStreamMatchConfidenceField,
+	GeocodeStreamMapboxIDField,
+	GeocodeStreamStatusField,
+	GeocodeStreamMessageField,
+}
+
+//////////////////////////////////////////////////////////////////
+
+type streamReader interface {
+	// next returns io.EOF once the input is exhausted
+	next() (*streamRecord, error)
+}
+
+type streamWriter interface {
+	write(record *streamRecord, result streamResult) error
+	flush() error
+}
+
+type csvStreamReader struct {
+	reader  *csv.Reader
+	header  []string
+	columns GeocodeStreamColumns
+	reverse bool
+}
+
+func newCSVStreamReader(r io.Reader, columns GeocodeStreamColumns, reverse bool) (*csvStreamReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // ragged rows are reported as invalid, not as a read error
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header. %w", err)
+	}
+
+	present := make(map[string]bool, len(header))
+	for _, name := range header {
+		present[name] = true
+	}
+	if reverse && (!present[columns.Latitude] || !present[columns.Longitude]) {
+		return nil, fmt.Errorf("csv header is missing %q or %q", columns.Latitude, columns.Longitude)
+	}
+	if !reverse && !present[columns.SearchText] && !present[columns.AddressLine1] {
+		return nil, fmt.Errorf("csv header is missing %q or %q", columns.SearchText, columns.AddressLine1)
+	}
+
+	return &csvStreamReader{reader: reader, header: header, columns: columns, reverse: reverse}, nil
+}
+
+func (s *csvStreamReader) next() (*streamRecord, error) {
+	row, err := s.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &streamRecord{
+		fields:  make(map[string]string, len(row)),
+		csv:     row,
+		columns: s.columns,
+	}
+	for i, name := range s.header {
+		if i < len(row) {
+			record.fields[name] = row[i]
+		}
+	}
+	record.validate(s.reverse)
+
+	if len(row) != len(s.header) {
+		record.invalid = fmt.Sprintf("expected %v fields, got %v", len(s.header), len(row))
+		// keep the result columns lined up with the header, extra fields go after them
+		record.csv = append(make([]string, 0, len(s.header)), row...)
+		for len(record.csv) < len(s.header) {
+			record.csv = append(record.csv, "")
+		}
+		record.extra = record.csv[len(s.header):]
+		record.csv = record.csv[:len(s.header)]
+	}
+	return record, nil
+}
+
+type csvStreamWriter struct {
+	writer      *csv.Writer
+	header      []string
+	writeHeader bool
+}
+
+func newCSVStreamWriter(w io.Writer, header []string, writeHeader bool) *csvStreamWriter {
+	return &csvStreamWriter{writer: csv.NewWriter(w), header: header, writeHeader: writeHeader}
+}
+
+func (s *csvStreamWriter) write(record *streamRecord, result streamResult) error {
+	if err := s.writeHeaderOnce(); err != nil {
+		return err
+	}
+
+	row := append(append([]string{}, record.csv...), result.values()...)
+	return s.writer.Write(append(row, record.extra...))
+}
+
+func (s *csvStreamWriter) writeHeaderOnce() error {
+	if !s.writeHeader {
+		return nil
+	}
+
+	s.writeHeader = false
+	return s.writer.Write(append(append([]string{}, s.header...), streamResultFields...))
+}
+
+// flush also writes the header of a fresh run without any records.
+func (s *csvStreamWriter) flush() error {
+	if err := s.writeHeaderOnce(); err != nil {
+		return err
+	}
+
+	s.writer.Flush()
+	return s.writer.Error()
+}
+
+type ndjsonStreamReader struct {
+	decoder *json.Decoder
+	columns GeocodeStreamColumns
+	reverse bool
+}
+
+func newNDJSONStreamReader(r io.Reader, columns GeocodeStreamColumns, reverse bool) *ndjsonStreamReader {
+	return &ndjsonStreamReader{decoder: json.NewDecoder(r), columns: columns, reverse: reverse}
+}
+
+func (s *ndjsonStreamReader) next() (*streamRecord, error) {
+	var object map[string]json.RawMessage
+	if err := s.decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	record := &streamRecord{
+		fields:  make(map[string]string, len(object)),
+		ndjson:  object,
+		columns: s.columns,
+	}
+	for key, raw := range object {
+		// accept both strings and numbers, e.g. for coordinates
+		var str string
+		if err := json.Unmarshal(raw, &str); err == nil {
+			record.fields[key] = str
+		} else {
+			record.fields[key] = string(raw)
+		}
+	}
+	record.validate(s.reverse)
+	return record, nil
+}
+
+type ndjsonStreamWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONStreamWriter(w io.Writer) *ndjsonStreamWriter {
+	return &ndjsonStreamWriter{encoder: json.NewEncoder(w)}
+}
+
+func (s *ndjsonStreamWriter) write(record *streamRecord, result streamResult) error {
+	object := make(map[string]interface{}, len(record.ndjson)+len(streamResultFields))
+	for key, value := range record.ndjson {
+		object[key] = value
+	}
+	for i, value := range result.values() {
+		if value != "" {
+			object[streamResultFields[i]] = value
+		}
+	}
+
+	return s.encoder.Encode(object)
+}
+
+func (s *ndjsonStreamWriter) flush() error {
+	return nil
+}
@@ -0,0 +1,246 @@
+package mapbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryCheckpoint struct {
+	n int
+}
+
+func (m *memoryCheckpoint) Load() (int, error) { return m.n, nil }
+func (m *memoryCheckpoint) Save(n int) error   { m.n = n; return nil }
+
+func batchHTTPResponse(features ...string) *http.Response {
+	results := make([]string, 0, len(features))
+	for _, feature := range features {
+		if feature == "" {
+			results = append(results, `{"type":"FeatureCollection","features":[]}`)
+			continue
+		}
+		results = append(results, `{"type":"FeatureCollection","features":[`+feature+`]}`)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"batch":[` + strings.Join(results, ",") + `]}`)),
+	}
+}
+
+func TestGeocodeStreamCSV(t *testing.T) {
+	input := "site,address,zip\n" +
+		"hq,6005 Hidden Valley Rd,92011\n" +
+		"empty,,\n" +
+		"lost,Nowhere,00000\n"
+
+	client, requests := mockClient(batchHTTPResponse(
+		`{"properties":{"mapbox_id":"abc","full_address":"6005 Hidden Valley Rd, Carlsbad, California 92011","coordinates":{"latitude":33.1,"longitude":-117.3},"match_code":{"confidence":"exact"}}}`,
+		"",
+	))
+
+	go func() { <-requests }()
+
+	var output bytes.Buffer
+	checkpoint := &memoryCheckpoint{}
+	err := client.GeocodeStream(context.Background(), &GeocodeStreamRequest{
+		Format:     GeocodeStreamFormatCSV,
+		Columns:    GeocodeStreamColumns{AddressLine1: "address", Postcode: "zip"},
+		Checkpoint: checkpoint,
+	}, strings.NewReader(input), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "site,address,zip,mapbox_latitude,mapbox_longitude,mapbox_full_address,mapbox_match_confidence,mapbox_id,mapbox_status,mapbox_message\n" +
+		"hq,6005 Hidden Valley Rd,92011,33.1,-117.3,\"6005 Hidden Valley Rd, Carlsbad, California 92011\",exact,abc,ok,\n" +
+		"empty,,,,,,,,invalid,missing search text or address\n" +
+		"lost,Nowhere,00000,,,,,,no_result,\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+
+	if checkpoint.n != 3 {
+		t.Errorf("expected checkpoint at 3 records, got %v", checkpoint.n)
+	}
+}
+
+func TestGeocodeStreamCSVRaggedRows(t *testing.T) {
+	input := "q,extra\n" +
+		"foo\n" +
+		"bar,1,2\n" +
+		"baz,3\n"
+
+	client, requests := mockClient(batchHTTPResponse(""))
+	go func() { <-requests }()
+
+	var output bytes.Buffer
+	err := client.GeocodeStream(context.Background(), &GeocodeStreamRequest{
+		Format:  GeocodeStreamFormatCSV,
+		Columns: GeocodeStreamColumns{SearchText: "q"},
+	}, strings.NewReader(input), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "q,extra,mapbox_latitude,mapbox_longitude,mapbox_full_address,mapbox_match_confidence,mapbox_id,mapbox_status,mapbox_message\n" +
+		"foo,,,,,,,invalid,\"expected 2 fields, got 1\"\n" +
+		"bar,1,,,,,,invalid,\"expected 2 fields, got 3\",2\n" +
+		"baz,3,,,,,,no_result,\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
+
+func TestGeocodeStreamCSVHeaderOnly(t *testing.T) {
+	client, _ := mockClient()
+
+	var output bytes.Buffer
+	err := client.GeocodeStream(context.Background(), &GeocodeStreamRequest{
+		Format:  GeocodeStreamFormatCSV,
+		Columns: GeocodeStreamColumns{SearchText: "q"},
+	}, strings.NewReader("q\n"), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "q,mapbox_latitude,mapbox_longitude,mapbox_full_address,mapbox_match_confidence,mapbox_id,mapbox_status,mapbox_message\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
+
+func TestGeocodeStreamNDJSONResume(t *testing.T) {
+	input := `{"id":1,"latitude":33.1,"longitude":-117.3}` + "\n" +
+		`{"id":2,"latitude":"32.7","longitude":"-117.1"}` + "\n"
+
+	client, requests := mockClient(batchHTTPResponse(
+		`{"properties":{"mapbox_id":"def","full_address":"San Diego","coordinates":{"latitude":32.7,"longitude":-117.1}}}`,
+	))
+
+	go func() { <-requests }()
+
+	// the first record was written by a previous run
+	var output bytes.Buffer
+	err := client.GeocodeStream(context.Background(), &GeocodeStreamRequest{
+		Format:     GeocodeStreamFormatNDJSON,
+		Reverse:    true,
+		Checkpoint: &memoryCheckpoint{n: 1},
+	}, strings.NewReader(input), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"id":2,"latitude":"32.7","longitude":"-117.1","mapbox_full_address":"San Diego","mapbox_id":"def",` +
+		`"mapbox_latitude":"32.7","mapbox_longitude":"-117.1","mapbox_status":"ok"}` + "\n"
+	if output.String() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, output.String())
+	}
+}
+
+func rateLimitedHTTPResponse() *http.Response {
+	header := http.Header{}
+	// already reset, so the retry waits the default second rather than until the reset
+	header.Set("X-Rate-Limit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+
+	return &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(`{"message":"Too Many Requests"}`)),
+	}
+}
+
+func TestGeocodeStreamChunksAndInterval(t *testing.T) {
+	client, requests := mockClient(
+		batchHTTPResponse("", ""),
+		batchHTTPResponse(""),
+	)
+
+	type sent struct {
+		at      time.Time
+		queries int
+	}
+	sentCh := make(chan []sent, 1)
+	go func() {
+		var all []sent
+		for i := 0; i < 2; i++ {
+			httpReq := <-requests
+			body, _ := httpReq.GetBody()
+			var queries []json.RawMessage
+			_ = json.NewDecoder(body).Decode(&queries)
+			all = append(all, sent{at: time.Now(), queries: len(queries)})
+		}
+		sentCh <- all
+	}()
+
+	interval := 200 * time.Millisecond
+	var output bytes.Buffer
+	err := client.GeocodeStream(context.Background(), &GeocodeStreamRequest{
+		Format:    GeocodeStreamFormatCSV,
+		Columns:   GeocodeStreamColumns{SearchText: "q"},
+		ChunkSize: 2,
+		Interval:  interval,
+	}, strings.NewReader("q\na\nb\nc\n"), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := <-sentCh
+	if all[0].queries != 2 || all[1].queries != 1 {
+		t.Errorf("expected chunks of 2 and 1 queries, got %v and %v", all[0].queries, all[1].queries)
+	}
+	if gap := all[1].at.Sub(all[0].at); gap < interval {
+		t.Errorf("expected at least %v between requests, got %v", interval, gap)
+	}
+	if lines := strings.Count(output.String(), "\n"); lines != 4 {
+		t.Errorf("expected a header and 3 records, got %v lines", lines)
+	}
+}
+
+func TestGeocodeStreamRateLimitRetry(t *testing.T) {
+	client, requests := mockClient(
+		rateLimitedHTTPResponse(),
+		batchHTTPResponse(`{"properties":{"mapbox_id":"abc"}}`),
+	)
+	go func() {
+		<-requests
+		<-requests
+	}()
+
+	var output bytes.Buffer
+	err := client.GeocodeStream(context.Background(), &GeocodeStreamRequest{
+		Format:  GeocodeStreamFormatCSV,
+		Columns: GeocodeStreamColumns{SearchText: "q"},
+	}, strings.NewReader("q\na\n"), &output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output.String(), "abc,ok") {
+		t.Errorf("expected the retried request to succeed, got:\n%s", output.String())
+	}
+
+	client, requests = mockClient(rateLimitedHTTPResponse(), rateLimitedHTTPResponse())
+	go func() {
+		<-requests
+		<-requests
+	}()
+
+	err = client.GeocodeStream(context.Background(), &GeocodeStreamRequest{
+		Format:     GeocodeStreamFormatCSV,
+		Columns:    GeocodeStreamColumns{SearchText: "q"},
+		MaxRetries: 1,
+	}, strings.NewReader("q\na\n"), &output)
+
+	var mapboxErr MapboxError
+	if !errors.As(err, &mapboxErr) || mapboxErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected to give up with the rate limit error, got %v", err)
+	}
+}
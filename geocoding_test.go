@@ -2,6 +2,12 @@ package mapbox
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -22,3 +28,181 @@ func TestForwardGeocodeURLEncoding(t *testing.T) {
 		SearchText: "query with special chars:/; ",
 	}, `/search/geocode/v6/forward?autocomplete=false&q=query+with+special+chars%3A%2F%3B+`)
 }
+
+func TestForwardGeocodeBatchPartialResults(t *testing.T) {
+	for _, statusCode := range []int{http.StatusOK, http.StatusUnprocessableEntity} {
+		body := `{"batch":[` +
+			`{"type":"FeatureCollection","features":[{"id":"a","type":"Feature","properties":{"mapbox_id":"a"}}]},` +
+			`{"type":"FeatureCollection","features":[]},` +
+			`{"message":"Query too long"}` +
+			`]}`
+
+		client, requests := mockClient(&http.Response{
+			StatusCode: statusCode,
+			Body:       io.NopCloser(strings.NewReader(body)),
+		})
+		go func() { <-requests }()
+
+		resp, err := client.ForwardGeocodeBatch(context.Background(), ForwardGeocodeBatchRequest{
+			{SearchText: "6005 Hidden Valley Rd, Carlsbad, CA"},
+			{SearchText: "nowhere"},
+			{SearchText: strings.Repeat("x", 300)},
+		})
+		if err != nil {
+			t.Fatalf("status %v: expected no error, got %v", statusCode, err)
+		}
+
+		expected := []GeocodeBatchStatus{GeocodeBatchStatusOK, GeocodeBatchStatusNoResult, GeocodeBatchStatusInvalid}
+		if len(resp.Batch) != len(expected) {
+			t.Fatalf("status %v: expected %v results, got %v", statusCode, len(expected), len(resp.Batch))
+		}
+		for i, status := range expected {
+			if resp.Batch[i].Status != status {
+				t.Errorf("status %v: expected result %v to be %v, got %v", statusCode, i, status, resp.Batch[i].Status)
+			}
+		}
+
+		if resp.Batch[0].Features[0].Properties.MapboxID != "a" {
+			t.Errorf("status %v: expected first result to keep its features", statusCode)
+		}
+		if err := resp.Batch[2].Err(); !errors.Is(err, ErrGeocodeBatchInvalid) || !strings.Contains(err.Error(), "Query too long") {
+			t.Errorf("status %v: expected invalid result error, got %v", statusCode, err)
+		}
+		if invalid := resp.Indexes(GeocodeBatchStatusInvalid); !reflect.DeepEqual(invalid, []int{2}) {
+			t.Errorf("status %v: expected invalid indexes [2], got %v", statusCode, invalid)
+		}
+	}
+}
+
+func TestForwardGeocodeBatchFailures(t *testing.T) {
+	for name, test := range map[string]struct {
+		statusCode int
+		body       string
+		mapboxErr  bool
+	}{
+		"all invalid":    {http.StatusUnprocessableEntity, `{"batch":[{"message":"bad"}],"message":"bad"}`, true},
+		"malformed item": {http.StatusUnprocessableEntity, `{"batch":[{"features":"x"},{"message":"bad"}],"message":"bad"}`, true},
+		"malformed ok":   {http.StatusOK, `{"batch":[{"type":"FeatureCollection","features":"x"}]}`, false},
+	} {
+		client, requests := mockClient(&http.Response{
+			StatusCode: test.statusCode,
+			Body:       io.NopCloser(strings.NewReader(test.body)),
+		})
+		go func() { <-requests }()
+
+		resp, err := client.ForwardGeocodeBatch(context.Background(), ForwardGeocodeBatchRequest{{SearchText: "x"}})
+		if err == nil || resp != nil {
+			t.Errorf("%v: expected an error, got %+v", name, resp)
+			continue
+		}
+
+		var mapboxErr MapboxError
+		if errors.As(err, &mapboxErr) != test.mapboxErr {
+			t.Errorf("%v: unexpected error %v", name, err)
+		}
+	}
+}
+
+func TestFeatureContextAccessors(t *testing.T) {
+	var properties Properties
+	err := json.Unmarshal([]byte(`{"context":{
+		"country":{"mapbox_id":"c1","name":"United States","country_code":"US","country_code_alpha_3":"USA",
+			"translations":{"es":{"language":"es","name":"Estados Unidos"}}},
+		"region":{"mapbox_id":"r1","name":"California","region_code":"CA","region_code_full":"US-CA"},
+		"postcode":{"mapbox_id":"p1","name":"92011"},
+		"address":{"mapbox_id":"a1","name":"6005 Hidden Valley Road","address_number":"6005","street_name":"Hidden Valley Road"}
+	}}`), &properties)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	country := properties.Context.Country()
+	if country == nil || country.CountryCode != "US" || country.Translations["es"].Name != "Estados Unidos" {
+		t.Errorf("unexpected country %+v", country)
+	}
+	if region := properties.Context.Region(); region == nil || region.RegionCode != "CA" || region.RegionCodeFull != "US-CA" {
+		t.Errorf("unexpected region %+v", region)
+	}
+	if postcode := properties.Context.Postcode(); postcode == nil || postcode.Name != "92011" {
+		t.Errorf("unexpected postcode %+v", postcode)
+	}
+	if address := properties.Context.Address(); address == nil || address.AddressNumber != "6005" || address.StreetName != "Hidden Valley Road" {
+		t.Errorf("unexpected address %+v", address)
+	}
+	if place := properties.Context.Place(); place != nil {
+		t.Errorf("expected no place, got %+v", place)
+	}
+
+	var searchboxProperties SearchboxReverseProperties
+	err = json.Unmarshal([]byte(`{"context":{"place":{"id":"pl1","name":"Carlsbad"}}}`), &searchboxProperties)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if place := searchboxProperties.Context.Place(); place == nil || place.MapboxID != "pl1" || place.Name != "Carlsbad" {
+		t.Errorf("unexpected searchbox place %+v", place)
+	}
+}
+
+func TestGeocodeResponseEvaluate(t *testing.T) {
+	var resp GeocodeResponse
+	err := json.Unmarshal([]byte(`{"features":[
+		{"id":"medium","properties":{"match_code":{"address_number":"matched","street":"matched","postcode":"unmatched","confidence":"medium"}}},
+		{"id":"inferred","properties":{"match_code":{"address_number":"matched","street":"matched","postcode":"inferred","confidence":"high"}}},
+		{"id":"exact","properties":{"match_code":{"address_number":"matched","street":"matched","postcode":"matched","confidence":"exact"}}},
+		{"id":"plausible","properties":{"match_code":{"address_number":"matched","street":"matched","postcode":"matched","place":"plausible","confidence":"exact"}}},
+		{"id":"inferred_optional","properties":{"match_code":{"address_number":"matched","street":"matched","postcode":"matched","locality":"inferred","confidence":"high"}}},
+		{"id":"not_applicable","properties":{"match_code":{"address_number":"not_applicable","street":"matched","postcode":"matched","confidence":"high"}}},
+		{"id":"place","properties":{}}
+	]}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	criteria := MatchCriteria{
+		MinConfidence: MatchCodeConfidenceHigh,
+		Required:      []MatchCodeComponent{MatchCodeComponentAddressNumber, MatchCodeComponentPostcode},
+	}
+
+	expected := []struct {
+		id      string
+		verdict MatchVerdict
+	}{
+		{"exact", MatchVerdictAccept},
+		{"plausible", MatchVerdictReview},         // plausible component which is not required
+		{"inferred", MatchVerdictReview},          // inferred required component
+		{"inferred_optional", MatchVerdictReview}, // inferred component which is not required
+		{"not_applicable", MatchVerdictReject},
+		{"medium", MatchVerdictReject},
+		{"place", MatchVerdictReject},
+	}
+
+	results := resp.Evaluate(criteria)
+	if len(results) != len(expected) {
+		t.Fatalf("expected %v results, got %v", len(expected), len(results))
+	}
+	for i, e := range expected {
+		if results[i].Feature.ID != e.id || results[i].Verdict != e.verdict {
+			t.Errorf("expected result %v to be %v/%v, got %v/%v", i, e.id, e.verdict, results[i].Feature.ID, results[i].Verdict)
+		}
+	}
+
+	if !reflect.DeepEqual(results[1].Plausible, []MatchCodeComponent{MatchCodeComponentPlace}) {
+		t.Errorf("expected place to be flagged plausible, got %v", results[1].Plausible)
+	}
+	if !reflect.DeepEqual(results[3].Inferred, []MatchCodeComponent{MatchCodeComponentLocality}) {
+		t.Errorf("expected locality to be flagged inferred, got %v", results[3].Inferred)
+	}
+	if !reflect.DeepEqual(results[4].Unmatched, []MatchCodeComponent{MatchCodeComponentAddressNumber}) {
+		t.Errorf("expected address number to be unmatched, got %v", results[4].Unmatched)
+	}
+	if !reflect.DeepEqual(results[5].Unmatched, []MatchCodeComponent{MatchCodeComponentPostcode}) {
+		t.Errorf("expected postcode to be unmatched, got %v", results[5].Unmatched)
+	}
+
+	if accepted := resp.Filter(criteria); len(accepted) != 1 || accepted[0].ID != "exact" {
+		t.Errorf("expected only the exact feature to be accepted, got %v", accepted)
+	}
+	if accepted := resp.Filter(MatchCriteria{}); len(accepted) != 4 {
+		t.Errorf("expected empty criteria to accept every unambiguous feature, got %v", len(accepted))
+	}
+}
@@ -0,0 +1,314 @@
+package mapbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	geocodingPath = "geocoding"
+
+	EndpointPlaces          = Endpoint("mapbox.places")
+	EndpointPlacesPermanent = Endpoint("mapbox.places-permanent")
+
+	ReverseModeDistance = ReverseMode("distance")
+	ReverseModeScore    = ReverseMode("score")
+)
+
+type ReverseMode string
+
+//////////////////////////////////////////////////////////////////
+
+// ForwardGeocodeV5Request is a query against the legacy Geocoding v5 API.
+type ForwardGeocodeV5Request struct {
+	// required
+	SearchText string
+
+	// optional
+	Endpoint     Endpoint // defaults to EndpointPlaces
+	Autocomplete *bool
+	BBox         BoundingBox
+	Country      string
+	FuzzyMatch   *bool
+	Language     string
+	Limit        int
+	Proximity    Coordinate
+	Routing      bool
+	Types        Types
+	Worldview    string
+}
+
+// ReverseGeocodeV5Request is a query against the legacy Geocoding v5 API.
+type ReverseGeocodeV5Request struct {
+	Coordinate
+
+	// optional
+	Endpoint    Endpoint // defaults to EndpointPlaces
+	Country     string
+	Language    string
+	Limit       int
+	ReverseMode ReverseMode
+	Routing     bool
+	Types       Types
+	Worldview   string
+}
+
+type GeocodeV5Response struct {
+	Type        string        `json:"type"`
+	Query       []interface{} `json:"query"` // the search terms, or [longitude, latitude] for reverse queries
+	Features    []*FeatureV5  `json:"features"`
+	Attribution string        `json:"attribution"`
+}
+
+// https://docs.mapbox.com/api/search/geocoding-v5/#geocoding-response-object
+type FeatureV5 struct {
+	ID                string              `json:"id"` // e.g. "address.4356035406756260"
+	Type              string              `json:"type"`
+	PlaceType         Types               `json:"place_type"`
+	Relevance         float64             `json:"relevance"`
+	Address           string              `json:"address,omitempty"` // the house number of address features
+	Properties        FeatureV5Properties `json:"properties"`
+	Text              string              `json:"text"`
+	PlaceName         string              `json:"place_name"`
+	MatchingText      string              `json:"matching_text,omitempty"`
+	MatchingPlaceName string              `json:"matching_place_name,omitempty"`
+	Language          string              `json:"language,omitempty"`
+	Center            []float64           `json:"center"` // [lng, lat]
+	Geometry          *Geometry           `json:"geometry"`
+	BoundingBox       []float64           `json:"bbox,omitempty"`
+	Context           []ContextV5         `json:"context,omitempty"`
+	RoutablePoints    *RoutablePointsV5   `json:"routable_points,omitempty"`
+
+	// Localized text_{language} and place_name_{language} values, keyed by language code
+	Texts      map[string]string `json:"-"`
+	PlaceNames map[string]string `json:"-"`
+}
+
+type FeatureV5Properties struct {
+	MapboxID  string `json:"mapbox_id,omitempty"`
+	Accuracy  string `json:"accuracy,omitempty"`
+	Address   string `json:"address,omitempty"`
+	Category  string `json:"category,omitempty"`
+	Maki      string `json:"maki,omitempty"`
+	Wikidata  string `json:"wikidata,omitempty"`
+	ShortCode string `json:"short_code,omitempty"`
+	Landmark  bool   `json:"landmark,omitempty"`
+}
+
+// ContextV5 is one of the parent features of a FeatureV5, ordered from the most to the least specific.
+type ContextV5 struct {
+	ID        string `json:"id"` // e.g. "postcode.8411948567567740"
+	MapboxID  string `json:"mapbox_id,omitempty"`
+	Text      string `json:"text"`
+	Wikidata  string `json:"wikidata,omitempty"`
+	ShortCode string `json:"short_code,omitempty"`
+
+	// Localized text_{language} values, keyed by language code
+	Texts map[string]string `json:"-"`
+}
+
+type RoutablePointsV5 struct {
+	Points []struct {
+		Coordinates []float64 `json:"coordinates"` // [lng, lat]
+	} `json:"points"`
+}
+
+func (f *FeatureV5) UnmarshalJSON(b []byte) error {
+	type featureV5 FeatureV5
+
+	var feature featureV5
+	if err := json.Unmarshal(b, &feature); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*f = FeatureV5(feature)
+	f.Texts = localizedFields(raw, "text_")
+	f.PlaceNames = localizedFields(raw, "place_name_")
+
+	return nil
+}
+
+func (c *ContextV5) UnmarshalJSON(b []byte) error {
+	type contextV5 ContextV5
+
+	var ctx contextV5
+	if err := json.Unmarshal(b, &ctx); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*c = ContextV5(ctx)
+	c.Texts = localizedFields(raw, "text_")
+
+	return nil
+}
+
+// Coordinate returns the center of the feature.
+func (f *FeatureV5) Coordinate() Coordinate {
+	if len(f.Center) < 2 {
+		return Coordinate{}
+	}
+	return Coordinate{Lat: f.Center[GeometryLatIdx], Lng: f.Center[GeometryLngIdx]}
+}
+
+// Type returns the kind of context, taken from the prefix of its ID.
+func (c ContextV5) Type() Type {
+	return Type(strings.SplitN(c.ID, ".", 2)[0])
+}
+
+// localizedFields collects the string values of keys like "text_es", keyed by language code.
+func localizedFields(raw map[string]json.RawMessage, prefix string) map[string]string {
+	var res map[string]string
+
+	for key, value := range raw {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			continue
+		}
+
+		if res == nil {
+			res = make(map[string]string)
+		}
+		res[strings.TrimPrefix(key, prefix)] = text
+	}
+
+	return res
+}
+
+//////////////////////////////////////////////////////////////////
+
+// https://docs.mapbox.com/api/search/geocoding-v5/#forward-geocoding
+func forwardGeocodeV5(ctx context.Context, client *Client, req *ForwardGeocodeV5Request) (*GeocodeV5Response, error) {
+	endpoint := req.Endpoint
+	if endpoint == "" {
+		endpoint = EndpointPlaces
+	}
+
+	// ';' would turn the query into a batch query
+	relPath := fmt.Sprintf("%v/%v/%v/%v.json", geocodingPath, v5, endpoint, url.PathEscape(req.SearchText))
+
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+
+	if req.Autocomplete != nil {
+		query.Set("autocomplete", strconv.FormatBool(*req.Autocomplete))
+	}
+
+	if !req.BBox.Min.IsZero() {
+		query.Set("bbox", req.BBox.query())
+	}
+
+	if req.Country != "" {
+		query.Set("country", req.Country)
+	}
+
+	if req.FuzzyMatch != nil {
+		query.Set("fuzzyMatch", strconv.FormatBool(*req.FuzzyMatch))
+	}
+
+	if req.Language != "" {
+		query.Set("language", req.Language)
+	}
+
+	if req.Limit != 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if !req.Proximity.IsZero() {
+		query.Set("proximity", req.Proximity.WGS84Format())
+	}
+
+	if req.Routing {
+		query.Set("routing", strconv.FormatBool(req.Routing))
+	}
+
+	if len(req.Types) != 0 {
+		query.Set("types", req.Types.query())
+	}
+
+	if req.Worldview != "" {
+		query.Set("worldview", req.Worldview)
+	}
+
+	apiResponse, err := client.get(ctx, relPath, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response GeocodeV5Response
+	if err := client.handleResponse(apiResponse, &response, GeocodingRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// https://docs.mapbox.com/api/search/geocoding-v5/#reverse-geocoding
+func reverseGeocodeV5(ctx context.Context, client *Client, req *ReverseGeocodeV5Request) (*GeocodeV5Response, error) {
+	endpoint := req.Endpoint
+	if endpoint == "" {
+		endpoint = EndpointPlaces
+	}
+
+	relPath := fmt.Sprintf("%v/%v/%v/%v.json", geocodingPath, v5, endpoint, req.Coordinate.WGS84Format())
+
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+
+	if req.Country != "" {
+		query.Set("country", req.Country)
+	}
+
+	if req.Language != "" {
+		query.Set("language", req.Language)
+	}
+
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if req.ReverseMode != "" {
+		query.Set("reverseMode", string(req.ReverseMode))
+	}
+
+	if req.Routing {
+		query.Set("routing", strconv.FormatBool(req.Routing))
+	}
+
+	if len(req.Types) > 0 {
+		query.Set("types", req.Types.query())
+	}
+
+	if req.Worldview != "" {
+		query.Set("worldview", req.Worldview)
+	}
+
+	apiResponse, err := client.get(ctx, relPath, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response GeocodeV5Response
+	if err := client.handleResponse(apiResponse, &response, GeocodingRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,59 @@
+package mapbox
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestGeocodeV5URLEncoding(t *testing.T) {
+	client, requests := mockClient()
+	go client.ForwardGeocodeV5(context.Background(), &ForwardGeocodeV5Request{
+		SearchText: "Main St; Suite 1/2",
+		Endpoint:   EndpointPlacesPermanent,
+		Types:      Types{TypeAddress, TypePOI},
+	})
+
+	expected := `/geocoding/v5/mapbox.places-permanent/Main%20St%3B%20Suite%201%2F2.json?types=address%2Cpoi`
+	if actual := (<-requests).URL.RequestURI(); actual != expected {
+		t.Errorf("expected:\n%s, got:\n%s", expected, actual)
+	}
+
+	go client.ReverseGeocodeV5(context.Background(), &ReverseGeocodeV5Request{
+		Coordinate:  Coordinate{Lat: 33.122508, Lng: -117.306786},
+		ReverseMode: ReverseModeScore,
+	})
+
+	expected = `/geocoding/v5/mapbox.places/-117.306786,33.122508.json?reverseMode=score`
+	if actual := (<-requests).URL.RequestURI(); actual != expected {
+		t.Errorf("expected:\n%s, got:\n%s", expected, actual)
+	}
+}
+
+func TestFeatureV5Unmarshal(t *testing.T) {
+	var feature FeatureV5
+	err := json.Unmarshal([]byte(`{
+		"id":"address.123","type":"Feature","place_type":["address"],"relevance":0.97,"address":"6005",
+		"properties":{"accuracy":"rooftop"},
+		"text":"Hidden Valley Road","text_es":"Hidden Valley Road","place_name_es":"6005 Hidden Valley Road, Carlsbad",
+		"place_name":"6005 Hidden Valley Road, Carlsbad, California 92011, United States",
+		"center":[-117.3,33.1],
+		"context":[{"id":"postcode.456","text":"92011"},{"id":"country.789","text":"United States","text_es":"Estados Unidos","short_code":"us"}]
+	}`), &feature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feature.Relevance != 0.97 || feature.Address != "6005" || feature.Properties.Accuracy != "rooftop" {
+		t.Errorf("unexpected feature %+v", feature)
+	}
+	if feature.PlaceNames["es"] != "6005 Hidden Valley Road, Carlsbad" || feature.Texts["es"] != "Hidden Valley Road" {
+		t.Errorf("unexpected localized fields %v %v", feature.Texts, feature.PlaceNames)
+	}
+	if c := feature.Coordinate(); c.Lat != 33.1 || c.Lng != -117.3 {
+		t.Errorf("unexpected coordinate %+v", c)
+	}
+	if len(feature.Context) != 2 || feature.Context[1].Type() != TypeCountry || feature.Context[1].Texts["es"] != "Estados Unidos" {
+		t.Errorf("unexpected context %+v", feature.Context)
+	}
+}
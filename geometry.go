@@ -0,0 +1,100 @@
+package mapbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// RouteGeometry is the geometry of a route or step, which Mapbox returns either as an
+// encoded polyline or as a GeoJSON LineString depending on the requested Geometries.
+// An encoded polyline does not say its precision, so a geometry decoded outside
+// Client.Directions, e.g. from a stored response, defaults to GeometriesPolyline. Set
+// Format to GeometriesPolyline6 before calling Coordinates if polyline6 was requested.
+type RouteGeometry struct {
+	// GeometriesGeoJSON, GeometriesPolyline or GeometriesPolyline6, empty when there is no geometry
+	Format Geometries
+
+	Encoded    string      // set for polyline formats
+	LineString [][]float64 // [lng, lat] pairs, set for GeometriesGeoJSON
+}
+
+func (g *RouteGeometry) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	switch {
+	case bytes.Equal(b, []byte("null")):
+		*g = RouteGeometry{}
+		return nil
+
+	case len(b) > 0 && b[0] == '"':
+		var encoded string
+		if err := json.Unmarshal(b, &encoded); err != nil {
+			return err
+		}
+		// the precision can't be told from the string, see setFormat
+		*g = RouteGeometry{Format: GeometriesPolyline, Encoded: encoded}
+		return nil
+
+	default:
+		var lineString struct {
+			Type        string      `json:"type"`
+			Coordinates [][]float64 `json:"coordinates"`
+		}
+		if err := json.Unmarshal(b, &lineString); err != nil {
+			return err
+		}
+		if lineString.Type != "LineString" {
+			return fmt.Errorf("unsupported geometry type %q", lineString.Type)
+		}
+		*g = RouteGeometry{Format: GeometriesGeoJSON, LineString: lineString.Coordinates}
+		return nil
+	}
+}
+
+func (g RouteGeometry) MarshalJSON() ([]byte, error) {
+	switch g.Format {
+	case GeometriesGeoJSON:
+		return json.Marshal(struct {
+			Type        string      `json:"type"`
+			Coordinates [][]float64 `json:"coordinates"`
+		}{Type: "LineString", Coordinates: g.LineString})
+	case GeometriesPolyline, GeometriesPolyline6:
+		return json.Marshal(g.Encoded)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// IsZero reports whether there is no geometry, e.g. for overview=false.
+func (g RouteGeometry) IsZero() bool {
+	return g.Format == ""
+}
+
+// Coordinates decodes the geometry, whichever format it came in.
+func (g RouteGeometry) Coordinates() (Coordinates, error) {
+	switch g.Format {
+	case GeometriesGeoJSON:
+		coordinates := make(Coordinates, 0, len(g.LineString))
+		for i, position := range g.LineString {
+			if len(position) < 2 {
+				return nil, fmt.Errorf("invalid LineString position %v", i)
+			}
+			coordinates = append(coordinates, Coordinate{Lat: position[GeometryLatIdx], Lng: position[GeometryLngIdx]})
+		}
+		return coordinates, nil
+	case GeometriesPolyline:
+		return DecodePolyline(g.Encoded, PolylinePrecision)
+	case GeometriesPolyline6:
+		return DecodePolyline(g.Encoded, Polyline6Precision)
+	default:
+		return nil, nil
+	}
+}
+
+// setFormat tells encoded geometries which polyline precision was requested.
+func (g *RouteGeometry) setFormat(requested Geometries) {
+	if g.Format == GeometriesPolyline && requested == GeometriesPolyline6 {
+		g.Format = GeometriesPolyline6
+	}
+}
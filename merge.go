@@ -0,0 +1,189 @@
+package mapbox
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	FeatureSourceGeocoding = FeatureSource("geocoding")
+	FeatureSourceSearchbox = FeatureSource("searchbox")
+
+	DefaultMergeMaxDistance   = 50  // meters
+	DefaultMergeMinSimilarity = 0.8 // see NameSimilarity
+)
+
+// FeatureSource names where a feature came from. Callers merging their own data can use
+// any name of their choosing.
+type FeatureSource string
+
+// MergedFeature is a feature which may have been reported by several sources.
+// Fields are taken from the first source which had them.
+type MergedFeature struct {
+	CommonFeature
+	Sources []FeatureProvenance
+}
+
+// FeatureProvenance records one of the features a MergedFeature was built from.
+type FeatureProvenance struct {
+	Source   FeatureSource
+	ID       string
+	Original interface{} // e.g. the *Feature or *SearchboxReverseFeature
+}
+
+// FeatureMerger deduplicates features reported by several sources. Two features are the
+// same if they share a Mapbox ID, or if they are within MaxDistance of each other and their
+// names are at least MinSimilarity alike.
+type FeatureMerger struct {
+	MaxDistance   float64
+	MinSimilarity float64
+
+	features []*MergedFeature
+}
+
+func NewFeatureMerger() *FeatureMerger {
+	return &FeatureMerger{
+		MaxDistance:   DefaultMergeMaxDistance,
+		MinSimilarity: DefaultMergeMinSimilarity,
+	}
+}
+
+// AddGeocodeResponse merges every feature of a geocoding response.
+func (m *FeatureMerger) AddGeocodeResponse(resp *GeocodeResponse) {
+	for _, feature := range resp.Features {
+		if feature == nil {
+			continue
+		}
+
+		m.Add(&MergedFeature{
+			CommonFeature: feature.Common(),
+			Sources:       []FeatureProvenance{{Source: FeatureSourceGeocoding, ID: feature.ID, Original: feature}},
+		})
+	}
+}
+
+// AddSearchboxReverseResponse merges every feature of a Search Box response.
+func (m *FeatureMerger) AddSearchboxReverseResponse(resp *SearchboxReverseResponse) {
+	for _, feature := range resp.Features {
+		if feature == nil {
+			continue
+		}
+
+		m.Add(&MergedFeature{
+			CommonFeature: feature.Common(),
+			Sources:       []FeatureProvenance{{Source: FeatureSourceSearchbox, ID: feature.ID, Original: feature}},
+		})
+	}
+}
+
+// Add merges a feature into the first known feature it duplicates, or keeps it as a new feature.
+func (m *FeatureMerger) Add(feature *MergedFeature) {
+	for _, existing := range m.features {
+		if m.duplicates(existing, feature) {
+			existing.merge(feature)
+			return
+		}
+	}
+
+	m.features = append(m.features, feature)
+}
+
+// Features returns the deduplicated features in the order they were first seen.
+func (m *FeatureMerger) Features() []*MergedFeature {
+	return m.features
+}
+
+func (m *FeatureMerger) duplicates(a, b *MergedFeature) bool {
+	if a.MapboxID != "" && b.MapboxID != "" {
+		return a.MapboxID == b.MapboxID
+	}
+
+	if a.Coordinate.IsZero() || b.Coordinate.IsZero() || a.Coordinate.DistanceTo(b.Coordinate) > m.MaxDistance {
+		return false
+	}
+
+	return NameSimilarity(a.Name, b.Name) >= m.MinSimilarity
+}
+
+func (f *MergedFeature) merge(o *MergedFeature) {
+	if f.MapboxID == "" {
+		f.MapboxID = o.MapboxID
+	}
+	if f.FeatureType == "" {
+		f.FeatureType = o.FeatureType
+	}
+	if f.Name == "" {
+		f.Name = o.Name
+	}
+	if f.FullAddress == "" {
+		f.FullAddress = o.FullAddress
+	}
+	if f.Coordinate.IsZero() {
+		f.Coordinate = o.Coordinate
+	}
+	if len(f.RoutablePoints) == 0 {
+		f.RoutablePoints = o.RoutablePoints
+	}
+	if len(f.Context) == 0 {
+		f.Context = o.Context
+	}
+	if len(f.BoundingBox) == 0 {
+		f.BoundingBox = o.BoundingBox
+	}
+
+	f.Sources = append(f.Sources, o.Sources...)
+}
+
+//////////////////////////////////////////////////////////////////
+
+// NameSimilarity scores how alike two names are, from 0 (nothing in common) to 1 (equal
+// ignoring case, punctuation and spacing).
+func NameSimilarity(a, b string) float64 {
+	ra, rb := []rune(normalizeName(a)), []rune(normalizeName(b))
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func normalizeName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = prev[j] + 1 // deletion
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1 // insertion
+			}
+			if prev[j-1]+cost < curr[j] {
+				curr[j] = prev[j-1] + cost // substitution
+			}
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,65 @@
+package mapbox
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFeatureMerger(t *testing.T) {
+	geocoded := &GeocodeResponse{Features: []*Feature{
+		{ID: "g1", Properties: &Properties{MapboxID: "a", Name: "Palomar Airport", Coordinates: ExtendedCoordinate{Latitude: 33.128, Longitude: -117.28}}},
+		{ID: "g2", Properties: &Properties{Name: "Tri-City Medical Center", FullAddress: "4002 Vista Way", Coordinates: ExtendedCoordinate{Latitude: 33.1823, Longitude: -117.2899}}},
+	}}
+	searched := &SearchboxReverseResponse{Features: []*SearchboxReverseFeature{
+		{ID: "s1", Properties: &SearchboxReverseProperties{MapboxID: "a", Name: "McClellan-Palomar Airport"}},
+		{ID: "s2", Properties: &SearchboxReverseProperties{Name: "Tri City Medical Center", FeatureType: TypePOI, Coordinates: ExtendedCoordinate{Latitude: 33.1824, Longitude: -117.2898}}},
+		{ID: "s3", Properties: &SearchboxReverseProperties{Name: "Tri City Urgent Care", Coordinates: ExtendedCoordinate{Latitude: 33.1824, Longitude: -117.2898}}},
+	}}
+
+	merger := NewFeatureMerger()
+	merger.AddGeocodeResponse(geocoded)
+	merger.AddSearchboxReverseResponse(searched)
+	merger.Add(&MergedFeature{
+		CommonFeature: CommonFeature{Name: "TRI-CITY MEDICAL CENTER", Coordinate: Coordinate{Lat: 33.1822, Lng: -117.29}},
+		Sources:       []FeatureProvenance{{Source: "own", ID: "poi-17"}},
+	})
+
+	features := merger.Features()
+	if len(features) != 3 {
+		t.Fatalf("expected 3 features, got %v", len(features))
+	}
+
+	airport := features[0]
+	if airport.Name != "Palomar Airport" || len(airport.Sources) != 2 || airport.Sources[1].ID != "s1" {
+		t.Errorf("expected features with the same mapbox id to merge, got %+v", airport)
+	}
+
+	hospital := features[1]
+	if len(hospital.Sources) != 3 || hospital.Sources[1].Source != FeatureSourceSearchbox || hospital.Sources[2].Source != "own" {
+		t.Errorf("expected nearby features with similar names to merge, got %+v", hospital.Sources)
+	}
+	if hospital.FeatureType != TypePOI || hospital.FullAddress != "4002 Vista Way" {
+		t.Errorf("expected missing fields to be filled from other sources, got %+v", hospital)
+	}
+
+	if features[2].Name != "Tri City Urgent Care" {
+		t.Errorf("expected dissimilar names to stay separate, got %+v", features[2])
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	if s := NameSimilarity("Tri-City Medical Center", "tri city medical center"); s != 1 {
+		t.Errorf("expected names differing in case and punctuation to be equal, got %v", s)
+	}
+	if s := NameSimilarity("kitten", "sitting"); math.Abs(s-(1-3.0/7)) > 1e-9 {
+		t.Errorf("unexpected similarity %v", s)
+	}
+}
+
+func TestCoordinateDistanceTo(t *testing.T) {
+	// Carlsbad to San Diego
+	d := Coordinate{Lat: 33.122508, Lng: -117.306786}.DistanceTo(Coordinate{Lat: 32.733810, Lng: -117.193443})
+	if math.Abs(d-44600) > 500 {
+		t.Errorf("unexpected distance %v", d)
+	}
+}
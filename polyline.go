@@ -0,0 +1,151 @@
+package mapbox
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	PolylinePrecision  = 5 // GeometriesPolyline
+	Polyline6Precision = 6 // GeometriesPolyline6
+)
+
+// EncodePolyline encodes the coordinates with the given precision, see
+// https://developers.google.com/maps/documentation/utilities/polylinealgorithm
+// Precisions and coordinates which DecodePolyline would reject are reported as errors.
+func EncodePolyline(coordinates Coordinates, precision int) (string, error) {
+	if err := checkPolylinePrecision(precision); err != nil {
+		return "", err
+	}
+	factor := math.Pow10(precision)
+
+	var b strings.Builder
+	b.Grow(len(coordinates) * 2 * (precision + 1))
+
+	var prevLat, prevLng int64
+	for i, c := range coordinates {
+		lat, lng := math.Round(c.Lat*factor), math.Round(c.Lng*factor)
+		// also rejects NaN
+		if !(math.Abs(lat) <= 90*factor) || !(math.Abs(lng) <= 180*factor) {
+			return "", fmt.Errorf("coordinate %v out of range: %v", i, c)
+		}
+
+		encodePolylineValue(&b, int64(lat)-prevLat)
+		encodePolylineValue(&b, int64(lng)-prevLng)
+
+		prevLat, prevLng = int64(lat), int64(lng)
+	}
+
+	return b.String(), nil
+}
+
+// DecodePolyline decodes an encoded polyline with the given precision. Malformed input and
+// coordinates out of the WGS84 range are reported as errors.
+func DecodePolyline(encoded string, precision int) (Coordinates, error) {
+	if err := checkPolylinePrecision(precision); err != nil {
+		return nil, err
+	}
+	factor := math.Pow10(precision)
+
+	coordinates := make(Coordinates, 0, len(encoded)/4)
+
+	var lat, lng int64
+	for i := 0; i < len(encoded); {
+		dLat, next, err := decodePolylineValue(encoded, i)
+		if err != nil {
+			return nil, err
+		}
+		if next == len(encoded) {
+			return nil, fmt.Errorf("polyline ends with a latitude without a longitude at offset %v", i)
+		}
+
+		dLng, next, err := decodePolylineValue(encoded, next)
+		if err != nil {
+			return nil, err
+		}
+		i = next
+
+		lat += dLat
+		lng += dLng
+		if math.Abs(float64(lat)) > 90*factor || math.Abs(float64(lng)) > 180*factor {
+			return nil, fmt.Errorf("polyline coordinate out of range at offset %v", i)
+		}
+
+		coordinates = append(coordinates, Coordinate{Lat: float64(lat) / factor, Lng: float64(lng) / factor})
+	}
+
+	return coordinates, nil
+}
+
+func EncodePolyline5(coordinates Coordinates) (string, error) {
+	return EncodePolyline(coordinates, PolylinePrecision)
+}
+
+func DecodePolyline5(encoded string) (Coordinates, error) {
+	return DecodePolyline(encoded, PolylinePrecision)
+}
+
+func EncodePolyline6(coordinates Coordinates) (string, error) {
+	return EncodePolyline(coordinates, Polyline6Precision)
+}
+
+func DecodePolyline6(encoded string) (Coordinates, error) {
+	return DecodePolyline(encoded, Polyline6Precision)
+}
+
+// checkPolylinePrecision keeps coordinates scaled by the precision well within int64.
+func checkPolylinePrecision(precision int) error {
+	if precision < 0 || precision > 10 {
+		return fmt.Errorf("invalid polyline precision %v", precision)
+	}
+	return nil
+}
+
+func encodePolylineValue(b *strings.Builder, value int64) {
+	// zig-zag encode the sign into the lowest bit
+	v := uint64(value) << 1
+	if value < 0 {
+		v = ^v
+	}
+
+	for v >= 0x20 {
+		b.WriteByte(byte((0x20 | (v & 0x1f)) + 63))
+		v >>= 5
+	}
+	b.WriteByte(byte(v + 63))
+}
+
+func decodePolylineValue(encoded string, i int) (int64, int, error) {
+	var result uint64
+	var shift uint
+
+	for start := i; ; i++ {
+		if i >= len(encoded) {
+			return 0, 0, fmt.Errorf("polyline ends in the middle of a value at offset %v", start)
+		}
+
+		c := encoded[i]
+		if c < 63 || c > 126 {
+			return 0, 0, fmt.Errorf("invalid polyline character %q at offset %v", c, i)
+		}
+		if shift > 55 {
+			return 0, 0, fmt.Errorf("polyline value too long at offset %v", start)
+		}
+
+		chunk := uint64(c - 63)
+		result |= (chunk & 0x1f) << shift
+		shift += 5
+
+		if chunk < 0x20 {
+			break
+		}
+	}
+
+	value := int64(result >> 1)
+	if result&1 != 0 {
+		value = ^value
+	}
+
+	return value, i + 1, nil
+}
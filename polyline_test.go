@@ -0,0 +1,138 @@
+package mapbox
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestPolyline(t *testing.T) {
+	// example from https://developers.google.com/maps/documentation/utilities/polylinealgorithm
+	coordinates := Coordinates{
+		{Lat: 38.5, Lng: -120.2},
+		{Lat: 40.7, Lng: -120.95},
+		{Lat: 43.252, Lng: -126.453},
+	}
+
+	encoded, err := EncodePolyline5(coordinates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoded != "_p~iF~ps|U_ulLnnqC_mqNvxq`@" {
+		t.Errorf("unexpected polyline %q", encoded)
+	}
+
+	decoded, err := DecodePolyline5(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, coordinates) {
+		t.Errorf("expected %v, got %v", coordinates, decoded)
+	}
+
+	encoded6, err := EncodePolyline6(coordinates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded6, err := DecodePolyline6(encoded6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded6, coordinates) {
+		t.Errorf("expected %v, got %v", coordinates, decoded6)
+	}
+}
+
+func TestDecodePolylineMalformed(t *testing.T) {
+	for name, encoded := range map[string]string{
+		"truncated value":     "_p~iF~ps|",
+		"missing longitude":   "_p~iF",
+		"invalid character":   "_p~iF~ps|U ",
+		"value too long":      "~~~~~~~~~~~~~~~~~~~~?",
+		"latitude over range": "_gsia@?",
+	} {
+		if _, err := DecodePolyline5(encoded); err == nil {
+			t.Errorf("%v: expected an error decoding %q", name, encoded)
+		}
+	}
+
+	for _, precision := range []int{-1, 11, 18} {
+		if _, err := DecodePolyline("", precision); err == nil {
+			t.Errorf("expected an error decoding with precision %v", precision)
+		}
+		if _, err := EncodePolyline(Coordinates{{Lat: 1, Lng: 1}}, precision); err == nil {
+			t.Errorf("expected an error encoding with precision %v", precision)
+		}
+	}
+	for _, c := range []Coordinate{{Lat: 91, Lng: 0}, {Lat: 0, Lng: -181}, {Lat: math.NaN(), Lng: 0}} {
+		if _, err := EncodePolyline5(Coordinates{c}); err == nil {
+			t.Errorf("expected an error encoding %v", c)
+		}
+	}
+
+	if coordinates, err := DecodePolyline5(""); err != nil || len(coordinates) != 0 {
+		t.Errorf("expected no coordinates for an empty polyline, got %v, %v", coordinates, err)
+	}
+}
+
+func FuzzPolylineDecode(f *testing.F) {
+	f.Add("_p~iF~ps|U_ulLnnqC_mqNvxq`@", 5)
+	f.Add("_izlhA~rlgdF_{geC~ywl@", 6)
+	f.Add("", 5)
+	f.Add("?", 6)
+
+	f.Fuzz(func(t *testing.T, encoded string, precision int) {
+		precision = int(math.Abs(float64(precision % 7)))
+
+		decoded, err := DecodePolyline(encoded, precision)
+		if err != nil {
+			return
+		}
+
+		// decoding normalizes, so anything decoded must survive a round trip unchanged
+		encoded, err = EncodePolyline(decoded, precision)
+		if err != nil {
+			t.Fatalf("failed to re-encode decoded polyline: %v", err)
+		}
+		again, err := DecodePolyline(encoded, precision)
+		if err != nil {
+			t.Fatalf("failed to decode re-encoded polyline: %v", err)
+		}
+		if !reflect.DeepEqual(decoded, again) {
+			t.Fatalf("round trip changed %v into %v", decoded, again)
+		}
+	})
+}
+
+func FuzzPolylineEncode(f *testing.F) {
+	f.Add(38.5, -120.2, 43.252, -126.453)
+	f.Add(-90.0, 180.0, 90.0, -180.0)
+	f.Add(0.0, 0.0, 0.0, 0.0)
+
+	f.Fuzz(func(t *testing.T, lat1, lng1, lat2, lng2 float64) {
+		coordinates := Coordinates{{Lat: lat1, Lng: lng1}, {Lat: lat2, Lng: lng2}}
+		for _, c := range coordinates {
+			if math.IsNaN(c.Lat) || math.IsNaN(c.Lng) || math.Abs(c.Lat) > 90 || math.Abs(c.Lng) > 180 {
+				return
+			}
+		}
+
+		for _, precision := range []int{PolylinePrecision, Polyline6Precision} {
+			encoded, err := EncodePolyline(coordinates, precision)
+			if err != nil {
+				t.Fatalf("failed to encode %v: %v", coordinates, err)
+			}
+			decoded, err := DecodePolyline(encoded, precision)
+			if err != nil {
+				t.Fatalf("failed to decode encoded %v: %v", coordinates, err)
+			}
+
+			tolerance := 0.5/math.Pow10(precision) + 1e-9
+			for i := range coordinates {
+				if math.Abs(decoded[i].Lat-coordinates[i].Lat) > tolerance || math.Abs(decoded[i].Lng-coordinates[i].Lng) > tolerance {
+					t.Fatalf("precision %v: expected %v, got %v", precision, coordinates[i], decoded[i])
+				}
+			}
+		}
+	})
+}
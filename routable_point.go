@@ -0,0 +1,106 @@
+package mapbox
+
+import (
+	"fmt"
+)
+
+const (
+	RoutablePointDefault = "default"
+)
+
+// RoutablePointOptions chooses between the routable points of a feature, e.g. the
+// entrances of a building.
+type RoutablePointOptions struct {
+	Name     string     // prefer the point with this name
+	Origin   Coordinate // otherwise prefer the point nearest to the origin
+	Approach Approach   // side of the road to arrive on, see DirectionsRequest.TargetFeature
+}
+
+// RoutablePoint returns the routable point of the feature which best matches the options:
+// the point named opts.Name, else the point nearest to opts.Origin, else the point named
+// "default", else the first point. The feature's own coordinate is returned, with false,
+// when it has no routable points.
+func (c CommonFeature) RoutablePoint(opts RoutablePointOptions) (Coordinate, bool) {
+	if len(c.RoutablePoints) == 0 {
+		return c.Coordinate, false
+	}
+
+	if opts.Name != "" {
+		for _, point := range c.RoutablePoints {
+			if point.Name == opts.Name {
+				return point.Coordinate(), true
+			}
+		}
+	}
+
+	if !opts.Origin.IsZero() {
+		best := c.RoutablePoints[0].Coordinate()
+		for _, point := range c.RoutablePoints[1:] {
+			if point.Coordinate().DistanceTo(opts.Origin) < best.DistanceTo(opts.Origin) {
+				best = point.Coordinate()
+			}
+		}
+		return best, true
+	}
+
+	for _, point := range c.RoutablePoints {
+		if point.Name == RoutablePointDefault {
+			return point.Coordinate(), true
+		}
+	}
+
+	return c.RoutablePoints[0].Coordinate(), true
+}
+
+// RoutablePoint returns the best routable point of the feature, see CommonFeature.RoutablePoint.
+func (f *Feature) RoutablePoint(opts RoutablePointOptions) (Coordinate, bool) {
+	return f.Common().RoutablePoint(opts)
+}
+
+func (r RoutablePoint) Coordinate() Coordinate {
+	return Coordinate{Lat: r.Latitude, Lng: r.Longitude}
+}
+
+//////////////////////////////////////////////////////////////////
+
+// TargetFeature makes the feature's best routable point the drop-off location of the
+// coordinate at index i, and sets its approach when opts.Approach is set. A zero coordinate
+// at index i is replaced with the feature's coordinate. WaypointTargets and Approaches are
+// padded with empty entries to line up with Coordinates, and Steps is enabled since the API
+// only accepts waypoint targets along with steps.
+func (r *DirectionsRequest) TargetFeature(i int, feature AnyFeature, opts RoutablePointOptions) error {
+	if i < 0 || i >= len(r.Coordinates) {
+		return fmt.Errorf("waypoint index %v out of range for %v coordinates", i, len(r.Coordinates))
+	}
+
+	common := feature.Common()
+	if r.Coordinates[i].IsZero() {
+		r.Coordinates[i] = common.Coordinate
+	}
+
+	target, _ := common.RoutablePoint(opts)
+	if target.IsZero() {
+		return fmt.Errorf("feature %v has no coordinates", common.ID)
+	}
+
+	for len(r.WaypointTargets) < len(r.Coordinates) {
+		r.WaypointTargets = append(r.WaypointTargets, "")
+	}
+	r.WaypointTargets[i] = WaypointTarget(target.WGS84Format())
+	r.enableSteps()
+
+	if opts.Approach != "" {
+		for len(r.Approaches) < len(r.Coordinates) {
+			r.Approaches = append(r.Approaches, "")
+		}
+		r.Approaches[i] = opts.Approach
+	}
+
+	return nil
+}
+
+// enableSteps turns on Steps, which waypoint_targets requires.
+func (r *DirectionsRequest) enableSteps() {
+	steps := true
+	r.Steps = &steps
+}
@@ -4,13 +4,34 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"path"
 	"strconv"
+	"strings"
 )
 
 const (
-	SearchboxReverseEndpoint = "/search/searchbox/v1/reverse"
+	SearchboxReverseEndpoint  = "/search/searchbox/v1/reverse"
+	SearchboxSuggestEndpoint  = "/search/searchbox/v1/suggest"
+	SearchboxRetrieveEndpoint = "/search/searchbox/v1/retrieve"
+	SearchboxForwardEndpoint  = "/search/searchbox/v1/forward"
+	SearchboxCategoryEndpoint = "/search/searchbox/v1/category"
+	SearchboxListEndpoint     = "/search/searchbox/v1/list/category"
+
+	NavigationProfileDriving = NavigationProfile("driving")
+	NavigationProfileWalking = NavigationProfile("walking")
+	NavigationProfileCycling = NavigationProfile("cycling")
+
+	ETATypeNavigation = ETAType("navigation")
+
+	SARTypeIsochrone = SARType("isochrone")
 )
 
+type NavigationProfile string
+type ETAType string
+
+// SARType is the kind of search along a route
+type SARType string
+
 type SearchboxReverseRequest struct {
 	Coordinate
 
@@ -35,23 +56,25 @@ type SearchboxReverseFeature struct {
 }
 
 type SearchboxReverseProperties struct {
-	MapboxID       string                 `json:"mapbox_id"`
-	FeatureType    Type                   `json:"feature_type"`
-	Name           string                 `json:"name"`
-	NamePreferred  string                 `json:"name_preferred"`
-	PlaceFormatted string                 `json:"place_formatted"`
-	FullAddress    string                 `json:"full_address"`
-	Coordinates    ExtendedCoordinate     `json:"coordinates"`
-	Context        map[Type]Context       `json:"context,omitempty"`
-	BoundingBox    []float64              `json:"bbox,omitempty"`
-	Language       string                 `json:"language"`
-	Maki           string                 `json:"maki"`
-	POICategory    []string               `json:"poi_category"`
-	POICategoryIDs []string               `json:"poi_category_ids"`
-	Brand          []string               `json:"brand"`
-	BrandID        []string               `json:"brand_id"`
-	ExternalIDs    map[string]string      `json:"external_ids,omitempty"`
-	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	MapboxID       string             `json:"mapbox_id"`
+	FeatureType    Type               `json:"feature_type"`
+	Name           string             `json:"name"`
+	NamePreferred  string             `json:"name_preferred"`
+	PlaceFormatted string             `json:"place_formatted"`
+	FullAddress    string             `json:"full_address"`
+	Coordinates    ExtendedCoordinate `json:"coordinates"`
+	Context        FeatureContext     `json:"context,omitempty"`
+	BoundingBox    []float64          `json:"bbox,omitempty"`
+	Language       string             `json:"language"`
+	Maki           string             `json:"maki"`
+	POICategory    []string           `json:"poi_category"`
+	POICategoryIDs []string           `json:"poi_category_ids"`
+	Brand          []string           `json:"brand"`
+	BrandID        []string           `json:"brand_id"`
+	ExternalIDs    map[string]string  `json:"external_ids,omitempty"`
+	Metadata       *SearchboxMetadata `json:"metadata,omitempty"`
+	Distance       float64            `json:"distance,omitempty"` // meters from the origin or proximity
+	ETA            float64            `json:"eta,omitempty"`      // minutes from the origin
 }
 
 // https://docs.mapbox.com/api/search/search-box/#reverse-lookup
@@ -89,3 +112,384 @@ func searchboxReverse(ctx context.Context, client *Client, req *SearchboxReverse
 
 	return &response, nil
 }
+
+//////////////////////////////////////////////////////////////////
+
+type SearchboxSuggestRequest struct {
+	// required
+	SearchText   string
+	SessionToken string // see SessionTokenManager
+
+	// optional
+	BBox                  BoundingBox
+	Country               string
+	Language              string
+	Limit                 int
+	Proximity             Coordinate
+	Types                 Types
+	POICategory           []string
+	POICategoryExclusions []string
+
+	// optional, ETAs are only returned when all of these are set
+	NavigationProfile NavigationProfile
+	Origin            Coordinate
+	ETAType           ETAType
+}
+
+type SearchboxSuggestResponse struct {
+	Suggestions []*SearchboxSuggestion `json:"suggestions"`
+	Attribution string                 `json:"attribution"`
+}
+
+// SearchboxSuggestion has no coordinates, those are only returned when the
+// suggestion is retrieved.
+type SearchboxSuggestion struct {
+	MapboxID       string             `json:"mapbox_id"`
+	FeatureType    Type               `json:"feature_type"`
+	Name           string             `json:"name"`
+	NamePreferred  string             `json:"name_preferred"`
+	Address        string             `json:"address"`
+	FullAddress    string             `json:"full_address"`
+	PlaceFormatted string             `json:"place_formatted"`
+	Context        FeatureContext     `json:"context,omitempty"`
+	Language       string             `json:"language"`
+	Maki           string             `json:"maki"`
+	POICategory    []string           `json:"poi_category"`
+	POICategoryIDs []string           `json:"poi_category_ids"`
+	Brand          []string           `json:"brand"`
+	BrandID        []string           `json:"brand_id"`
+	ExternalIDs    map[string]string  `json:"external_ids,omitempty"`
+	Metadata       *SearchboxMetadata `json:"metadata,omitempty"`
+	Distance       float64            `json:"distance,omitempty"` // meters from the origin or proximity
+	ETA            float64            `json:"eta,omitempty"`      // minutes from the origin
+}
+
+type SearchboxRetrieveRequest struct {
+	// required
+	MapboxID     string // SearchboxSuggestion.MapboxID
+	SessionToken string // must be the token of the suggest requests that returned the suggestion
+
+	// optional, ETAs are only returned when all of these are set
+	NavigationProfile NavigationProfile
+	Origin            Coordinate
+	ETAType           ETAType
+}
+
+type SearchboxRetrieveResponse struct {
+	Type        string                     `json:"type"`
+	Features    []*SearchboxReverseFeature `json:"features"`
+	Attribution string                     `json:"attribution"`
+}
+
+// https://docs.mapbox.com/api/search/search-box/#get-suggested-results
+func searchboxSuggest(ctx context.Context, client *Client, req *SearchboxSuggestRequest) (*SearchboxSuggestResponse, error) {
+	if req.SessionToken == "" {
+		return nil, fmt.Errorf("missing Search Box session token")
+	}
+
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+	query.Set("q", req.SearchText)
+	query.Set("session_token", req.SessionToken)
+
+	if !req.BBox.Min.IsZero() {
+		query.Set("bbox", req.BBox.query())
+	}
+
+	if req.Country != "" {
+		query.Set("country", req.Country)
+	}
+
+	if req.Language != "" {
+		query.Set("language", req.Language)
+	}
+
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if !req.Proximity.IsZero() {
+		query.Set("proximity", req.Proximity.WGS84Format())
+	}
+
+	if len(req.Types) > 0 {
+		query.Set("types", req.Types.query())
+	}
+
+	if len(req.POICategory) > 0 {
+		query.Set("poi_category", strings.Join(req.POICategory, ","))
+	}
+
+	if len(req.POICategoryExclusions) > 0 {
+		query.Set("poi_category_exclusions", strings.Join(req.POICategoryExclusions, ","))
+	}
+
+	setSearchboxETA(query, req.NavigationProfile, req.Origin, req.ETAType)
+
+	apiResponse, err := client.get(ctx, SearchboxSuggestEndpoint, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SearchboxSuggestResponse
+	if err := client.handleResponse(apiResponse, &response, SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// https://docs.mapbox.com/api/search/search-box/#retrieve-a-suggested-feature
+func searchboxRetrieve(ctx context.Context, client *Client, req *SearchboxRetrieveRequest) (*SearchboxRetrieveResponse, error) {
+	if req.SessionToken == "" {
+		return nil, fmt.Errorf("missing Search Box session token")
+	}
+
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+	query.Set("session_token", req.SessionToken)
+
+	setSearchboxETA(query, req.NavigationProfile, req.Origin, req.ETAType)
+
+	apiResponse, err := client.get(ctx, path.Join(SearchboxRetrieveEndpoint, url.PathEscape(req.MapboxID)), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SearchboxRetrieveResponse
+	if err := client.handleResponse(apiResponse, &response, SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func setSearchboxETA(query url.Values, profile NavigationProfile, origin Coordinate, etaType ETAType) {
+	if profile != "" {
+		query.Set("navigation_profile", string(profile))
+	}
+
+	if !origin.IsZero() {
+		query.Set("origin", origin.WGS84Format())
+	}
+
+	if etaType != "" {
+		query.Set("eta_type", string(etaType))
+	}
+}
+
+//////////////////////////////////////////////////////////////////
+
+type SearchboxForwardRequest struct {
+	// required
+	SearchText string
+
+	// optional
+	AutoComplete          *bool
+	BBox                  BoundingBox
+	Country               string
+	Language              string
+	Limit                 int // at most 10
+	Proximity             Coordinate
+	Types                 Types
+	POICategory           []string
+	POICategoryExclusions []string
+
+	// optional, ETAs are only returned when all of these are set
+	NavigationProfile NavigationProfile
+	Origin            Coordinate
+	ETAType           ETAType
+}
+
+type SearchboxForwardResponse struct {
+	Type        string                     `json:"type"`
+	Features    []*SearchboxReverseFeature `json:"features"`
+	Attribution string                     `json:"attribution"`
+}
+
+// https://docs.mapbox.com/api/search/search-box/#search-request
+func searchboxForward(ctx context.Context, client *Client, req *SearchboxForwardRequest) (*SearchboxForwardResponse, error) {
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+	query.Set("q", req.SearchText)
+
+	if req.AutoComplete != nil {
+		query.Set("auto_complete", strconv.FormatBool(*req.AutoComplete))
+	}
+
+	if !req.BBox.Min.IsZero() {
+		query.Set("bbox", req.BBox.query())
+	}
+
+	if req.Country != "" {
+		query.Set("country", req.Country)
+	}
+
+	if req.Language != "" {
+		query.Set("language", req.Language)
+	}
+
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if !req.Proximity.IsZero() {
+		query.Set("proximity", req.Proximity.WGS84Format())
+	}
+
+	if len(req.Types) > 0 {
+		query.Set("types", req.Types.query())
+	}
+
+	if len(req.POICategory) > 0 {
+		query.Set("poi_category", strings.Join(req.POICategory, ","))
+	}
+
+	if len(req.POICategoryExclusions) > 0 {
+		query.Set("poi_category_exclusions", strings.Join(req.POICategoryExclusions, ","))
+	}
+
+	setSearchboxETA(query, req.NavigationProfile, req.Origin, req.ETAType)
+
+	apiResponse, err := client.get(ctx, SearchboxForwardEndpoint, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SearchboxForwardResponse
+	if err := client.handleResponse(apiResponse, &response, SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+//////////////////////////////////////////////////////////////////
+
+type SearchboxCategoryRequest struct {
+	// required
+	Category string // canonical category id, e.g. "hospital", see SearchboxListCategories
+
+	// optional
+	BBox                  BoundingBox
+	Country               string
+	Language              string
+	Limit                 int // at most 25
+	Proximity             Coordinate
+	POICategoryExclusions []string
+
+	// optional, search along a route
+	Route         string     // encoded polyline of the route
+	RouteGeometry Geometries // GeometriesPolyline or GeometriesPolyline6
+	SARType       SARType
+	TimeDeviation int // maximum detour in minutes
+}
+
+type SearchboxCategoryResponse struct {
+	Type        string                     `json:"type"`
+	Features    []*SearchboxReverseFeature `json:"features"`
+	Attribution string                     `json:"attribution"`
+}
+
+type SearchboxListCategoriesRequest struct {
+	// optional
+	Language string
+}
+
+type SearchboxListCategoriesResponse struct {
+	Categories  []SearchboxCategory `json:"listItems"`
+	Attribution string              `json:"attribution"`
+	Version     string              `json:"version"`
+}
+
+type SearchboxCategory struct {
+	CanonicalID string `json:"canonical_id"`
+	Icon        string `json:"icon"` // Maki icon name
+	Name        string `json:"name"`
+	UUID        string `json:"uuid,omitempty"`
+	Version     string `json:"version,omitempty"`
+}
+
+// https://docs.mapbox.com/api/search/search-box/#category-search
+func searchboxCategory(ctx context.Context, client *Client, req *SearchboxCategoryRequest) (*SearchboxCategoryResponse, error) {
+	if req.Category == "" {
+		return nil, fmt.Errorf("missing Search Box category")
+	}
+
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+
+	if !req.BBox.Min.IsZero() {
+		query.Set("bbox", req.BBox.query())
+	}
+
+	if req.Country != "" {
+		query.Set("country", req.Country)
+	}
+
+	if req.Language != "" {
+		query.Set("language", req.Language)
+	}
+
+	if req.Limit > 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+
+	if !req.Proximity.IsZero() {
+		query.Set("proximity", req.Proximity.WGS84Format())
+	}
+
+	if len(req.POICategoryExclusions) > 0 {
+		query.Set("poi_category_exclusions", strings.Join(req.POICategoryExclusions, ","))
+	}
+
+	if req.Route != "" {
+		query.Set("route", req.Route)
+	}
+
+	if req.RouteGeometry != "" {
+		query.Set("route_geometry", string(req.RouteGeometry))
+	}
+
+	if req.SARType != "" {
+		query.Set("sar_type", string(req.SARType))
+	}
+
+	if req.TimeDeviation > 0 {
+		query.Set("time_deviation", strconv.Itoa(req.TimeDeviation))
+	}
+
+	apiResponse, err := client.get(ctx, path.Join(SearchboxCategoryEndpoint, url.PathEscape(req.Category)), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SearchboxCategoryResponse
+	if err := client.handleResponse(apiResponse, &response, SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// https://docs.mapbox.com/api/search/search-box/#list-categories
+func searchboxListCategories(ctx context.Context, client *Client, req *SearchboxListCategoriesRequest) (*SearchboxListCategoriesResponse, error) {
+	query := url.Values{}
+	query.Set("access_token", client.apiKey)
+
+	if req.Language != "" {
+		query.Set("language", req.Language)
+	}
+
+	apiResponse, err := client.get(ctx, SearchboxListEndpoint, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SearchboxListCategoriesResponse
+	if err := client.handleResponse(apiResponse, &response, SearchboxRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,176 @@
+package mapbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+// SearchboxMetadata holds the optional details Mapbox has about a POI. Raw keeps every key,
+// including the ones which are not modeled here.
+// https://docs.mapbox.com/api/search/search-box/#the-metadata-object
+type SearchboxMetadata struct {
+	Phone        string           `json:"phone,omitempty"`
+	Website      string           `json:"website,omitempty"`
+	Email        string           `json:"email,omitempty"`
+	Fax          string           `json:"fax,omitempty"`
+	Rating       float64          `json:"rating,omitempty"`
+	ReviewCount  int              `json:"review_count,omitempty"`
+	Popularity   float64          `json:"popularity,omitempty"`
+	PriceLevel   string           `json:"price_level,omitempty"`
+	OpenHours    *OpenHours       `json:"open_hours,omitempty"`
+	PrimaryPhoto []SearchboxPhoto `json:"primary_photo,omitempty"`
+	OtherPhoto   []SearchboxPhoto `json:"other_photo,omitempty"`
+
+	Facebook  string `json:"facebook_id,omitempty"`
+	Instagram string `json:"instagram,omitempty"`
+	Twitter   string `json:"twitter,omitempty"`
+
+	WheelchairAccessible *bool `json:"wheelchair_accessible,omitempty"`
+	Delivery             *bool `json:"delivery,omitempty"`
+	DriveThrough         *bool `json:"drive_through,omitempty"`
+	Reservable           *bool `json:"reservable,omitempty"`
+	ParkingAvailable     *bool `json:"parking_available,omitempty"`
+	ValetParking         *bool `json:"valet_parking,omitempty"`
+	StreetParking        *bool `json:"street_parking,omitempty"`
+	Takeout              *bool `json:"takeout,omitempty"`
+	WiFi                 *bool `json:"wifi,omitempty"`
+
+	// Every metadata key, including the ones modeled above
+	Raw map[string]interface{} `json:"-"`
+}
+
+type SearchboxPhoto struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+func (m *SearchboxMetadata) UnmarshalJSON(b []byte) error {
+	type searchboxMetadata SearchboxMetadata
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, &m.Raw); err != nil {
+		return err
+	}
+
+	// decode the known fields one by one, so a field shaped differently than modeled
+	// is only left out instead of failing the whole response
+	var metadata searchboxMetadata
+	for key, value := range fields {
+		field, err := json.Marshal(map[string]json.RawMessage{key: value})
+		if err != nil {
+			continue
+		}
+		_ = json.Unmarshal(field, &metadata)
+	}
+
+	raw := m.Raw
+	*m = SearchboxMetadata(metadata)
+	m.Raw = raw
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////
+
+const (
+	OpenTypeAlwaysOpen        = OpenType("always_opened")
+	OpenTypeTemporarilyClosed = OpenType("temporarily_closed")
+	OpenTypePermanentlyClosed = OpenType("permanently_closed")
+	OpenTypeScheduled         = OpenType("scheduled") // see Periods
+)
+
+type OpenType string
+
+// OpenHours is the weekly schedule of a POI, in the POI's local time.
+type OpenHours struct {
+	OpenType OpenType     `json:"open_type,omitempty"`
+	Periods  []OpenPeriod `json:"periods"`
+}
+
+// OpenPeriod is a single opening of a POI. A period without Close is open around the clock.
+type OpenPeriod struct {
+	Open  OpenTime  `json:"open"`
+	Close *OpenTime `json:"close,omitempty"`
+}
+
+type OpenTime struct {
+	Day  time.Weekday `json:"day"`  // 0 is Sunday
+	Time string       `json:"time"` // "HHMM", e.g. "0930"
+}
+
+// IsOpenAt reports whether the POI is open at t, closed POIs are never open and always open
+// POIs need no periods. Since opening hours are in the POI's local time, t should be in the
+// POI's time zone.
+func (o *OpenHours) IsOpenAt(t time.Time) bool {
+	if o == nil {
+		return false
+	}
+
+	switch o.OpenType {
+	case OpenTypeAlwaysOpen:
+		return true
+	case OpenTypeTemporarilyClosed, OpenTypePermanentlyClosed:
+		return false
+	case OpenTypeScheduled:
+		// see the periods below, which are also used when the type is missing
+	}
+
+	now := int(t.Weekday())*minutesPerDay + t.Hour()*60 + t.Minute()
+
+	for _, period := range o.Periods {
+		open, err := period.Open.minuteOfWeek()
+		if err != nil {
+			continue
+		}
+		if period.Close == nil {
+			return true
+		}
+		closing, err := period.Close.minuteOfWeek()
+		if err != nil {
+			continue
+		}
+
+		// the period wraps around the end of the week, e.g. Saturday night to Sunday morning
+		if closing <= open {
+			closing += minutesPerWeek
+		}
+
+		if (now >= open && now < closing) || (now+minutesPerWeek >= open && now+minutesPerWeek < closing) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (o OpenTime) minuteOfWeek() (int, error) {
+	if len(o.Time) != 4 || o.Day < time.Sunday || o.Day > time.Saturday {
+		return 0, fmt.Errorf("invalid open time %v %q", o.Day, o.Time)
+	}
+
+	hours, err := strconv.Atoi(o.Time[:2])
+	if err != nil {
+		return 0, err
+	}
+	minutes, err := strconv.Atoi(o.Time[2:])
+	if err != nil {
+		return 0, err
+	}
+
+	// "2400" is used for midnight at the end of a day
+	if hours > 24 || minutes > 59 {
+		return 0, fmt.Errorf("invalid open time %v %q", o.Day, o.Time)
+	}
+
+	return int(o.Day)*minutesPerDay + hours*60 + minutes, nil
+}
@@ -0,0 +1,248 @@
+package mapbox
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func checkSearchboxRequestURL(t *testing.T, send func(client *Client), expectedURL string) {
+	t.Helper()
+	client, requests := mockClient()
+	go send(client)
+
+	httpReq := <-requests
+	actualURL := httpReq.URL.RequestURI()
+	if expectedURL != actualURL {
+		t.Errorf("expected:\n%s, got:\n%s", expectedURL, actualURL)
+	}
+}
+
+func TestSearchboxSuggestURLEncoding(t *testing.T) {
+	checkSearchboxRequestURL(t, func(client *Client) {
+		client.SearchboxSuggest(context.Background(), &SearchboxSuggestRequest{
+			SearchText:        "hidden valley",
+			SessionToken:      "token",
+			Proximity:         Coordinate{Lat: 33.122508, Lng: -117.306786},
+			Types:             Types{TypePOI, TypeAddress},
+			POICategory:       []string{"hospital", "helipad"},
+			NavigationProfile: NavigationProfileDriving,
+			Origin:            Coordinate{Lat: 33.1, Lng: -117.3},
+			ETAType:           ETATypeNavigation,
+		})
+	}, `/search/searchbox/v1/suggest?eta_type=navigation&navigation_profile=driving&origin=-117.3%2C33.1&poi_category=hospital%2Chelipad&proximity=-117.306786%2C33.122508&q=hidden+valley&session_token=token&types=poi%2Caddress`)
+}
+
+func TestSearchboxRetrieveURLEncoding(t *testing.T) {
+	checkSearchboxRequestURL(t, func(client *Client) {
+		client.SearchboxRetrieve(context.Background(), &SearchboxRetrieveRequest{
+			MapboxID:     "dXJuOm1ieHBvaTo/abc",
+			SessionToken: "token",
+		})
+	}, `/search/searchbox/v1/retrieve/dXJuOm1ieHBvaTo%2Fabc?session_token=token`)
+}
+
+func TestSearchboxSuggestMissingSessionToken(t *testing.T) {
+	client, _ := mockClient()
+	if _, err := client.SearchboxSuggest(context.Background(), &SearchboxSuggestRequest{SearchText: "x"}); err == nil {
+		t.Error("expected an error without a session token")
+	}
+}
+
+func TestSessionTokenManager(t *testing.T) {
+	now := time.Now()
+	m := NewSessionTokenManager(time.Minute)
+	m.now = func() time.Time { return now }
+	token := func(key string) string {
+		t.Helper()
+		s, err := m.Token(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	first := token("user")
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(first) {
+		t.Errorf("expected a UUIDv4 token, got %q", first)
+	}
+
+	if token("user") != first {
+		t.Error("expected the session token to be reused")
+	}
+	if token("other") == first {
+		t.Error("expected separate sessions per key")
+	}
+
+	m.End("user")
+	ended := token("user")
+	if ended == first {
+		t.Error("expected a new session token after the session ended")
+	}
+
+	now = now.Add(time.Minute)
+	if token("user") == ended {
+		t.Error("expected a new session token after the session expired")
+	}
+
+	// other keys are pruned once per ttl, not on every call
+	now = now.Add(30 * time.Second)
+	token("late")
+	now = now.Add(30 * time.Second) // prunes, late has not expired yet
+	token("user")
+	now = now.Add(30 * time.Second) // late has expired
+	token("user")
+	if _, ok := m.sessions["late"]; !ok {
+		t.Error("expected the expired session to be kept until the next prune")
+	}
+	now = now.Add(30 * time.Second)
+	token("user")
+	if _, ok := m.sessions["late"]; ok {
+		t.Error("expected the expired session to be pruned")
+	}
+}
+
+func TestSearchboxForwardURLEncoding(t *testing.T) {
+	falseVal := false
+
+	checkSearchboxRequestURL(t, func(client *Client) {
+		client.SearchboxForward(context.Background(), &SearchboxForwardRequest{
+			SearchText:   "fuel",
+			AutoComplete: &falseVal,
+			Country:      "us",
+			Limit:        10,
+			POICategory:  []string{"gas_station"},
+		})
+	}, `/search/searchbox/v1/forward?auto_complete=false&country=us&limit=10&poi_category=gas_station&q=fuel`)
+}
+
+func TestSearchboxCategoryURLEncoding(t *testing.T) {
+	checkSearchboxRequestURL(t, func(client *Client) {
+		client.SearchboxCategory(context.Background(), &SearchboxCategoryRequest{
+			Category:      "helipad",
+			Limit:         25,
+			Proximity:     Coordinate{Lat: 33.122508, Lng: -117.306786},
+			Route:         "_p~iF~ps|U_ulLnnqC",
+			RouteGeometry: GeometriesPolyline,
+			SARType:       SARTypeIsochrone,
+			TimeDeviation: 15,
+		})
+	}, `/search/searchbox/v1/category/helipad?limit=25&proximity=-117.306786%2C33.122508&route=_p~iF~ps%7CU_ulLnnqC&route_geometry=polyline&sar_type=isochrone&time_deviation=15`)
+}
+
+func TestSearchboxListCategories(t *testing.T) {
+	client, requests := mockClient(&http.Response{
+		StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`{"listItems":[` +
+			`{"canonical_id":"hospital","icon":"hospital","name":"Hospital"},` +
+			`{"canonical_id":"gas_station","icon":"fuel","name":"Gas Station"}],"version":"1"}`)),
+	})
+	go func() { <-requests }()
+
+	resp, err := client.SearchboxListCategories(context.Background(), &SearchboxListCategoriesRequest{Language: "en"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Categories) != 2 || resp.Categories[1].CanonicalID != "gas_station" || resp.Categories[1].Icon != "fuel" {
+		t.Errorf("unexpected categories %+v", resp.Categories)
+	}
+}
+
+func TestSearchboxMetadata(t *testing.T) {
+	var properties SearchboxReverseProperties
+	err := json.Unmarshal([]byte(`{
+		"distance":1520.5,"eta":4.2,
+		"metadata":{
+			"phone":"+1 760-555-0100","website":"https://example.com","rating":4.5,"review_count":120,
+			"wheelchair_accessible":true,"primary_photo":[{"url":"https://example.com/a.jpg","width":640,"height":480}],
+			"heliport_code":"KCRQ",
+			"open_hours":{"periods":[
+				{"open":{"day":1,"time":"0800"},"close":{"day":1,"time":"1700"}},
+				{"open":{"day":6,"time":"2200"},"close":{"day":0,"time":"0200"}}
+			]}
+		}
+	}`), &properties)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if properties.Distance != 1520.5 || properties.ETA != 4.2 {
+		t.Errorf("unexpected distance %v and eta %v", properties.Distance, properties.ETA)
+	}
+
+	metadata := properties.Metadata
+	if metadata.Phone != "+1 760-555-0100" || metadata.Rating != 4.5 || metadata.ReviewCount != 120 {
+		t.Errorf("unexpected metadata %+v", metadata)
+	}
+	if metadata.WheelchairAccessible == nil || !*metadata.WheelchairAccessible {
+		t.Error("expected POI to be wheelchair accessible")
+	}
+	if len(metadata.PrimaryPhoto) != 1 || metadata.PrimaryPhoto[0].Width != 640 {
+		t.Errorf("unexpected primary photo %+v", metadata.PrimaryPhoto)
+	}
+	if metadata.Raw["heliport_code"] != "KCRQ" {
+		t.Errorf("expected unmodeled keys to be kept, got %v", metadata.Raw)
+	}
+
+	// 2024-01-01 is a Monday
+	tests := map[string]bool{
+		"2024-01-01T07:59:00Z": false,
+		"2024-01-01T08:00:00Z": true,
+		"2024-01-01T16:59:00Z": true,
+		"2024-01-01T17:00:00Z": false,
+		"2024-01-06T23:00:00Z": true, // Saturday night
+		"2024-01-07T01:30:00Z": true, // Sunday morning
+		"2024-01-07T02:00:00Z": false,
+	}
+	for at, open := range tests {
+		tm, _ := time.Parse(time.RFC3339, at)
+		if metadata.OpenHours.IsOpenAt(tm) != open {
+			t.Errorf("expected open at %v to be %v", at, open)
+		}
+	}
+
+	alwaysOpen := OpenHours{Periods: []OpenPeriod{{Open: OpenTime{Day: time.Sunday, Time: "0000"}}}}
+	if !alwaysOpen.IsOpenAt(time.Now()) {
+		t.Error("expected a period without close to always be open")
+	}
+
+	var openTypes struct {
+		AlwaysOpen        OpenHours `json:"always_open"`
+		TemporarilyClosed OpenHours `json:"temporarily_closed"`
+	}
+	if err := json.Unmarshal([]byte(`{
+		"always_open":{"open_type":"always_opened"},
+		"temporarily_closed":{"open_type":"temporarily_closed","periods":[{"open":{"day":0,"time":"0000"}}]}
+	}`), &openTypes); err != nil {
+		t.Fatal(err)
+	}
+	if !openTypes.AlwaysOpen.IsOpenAt(time.Now()) {
+		t.Error("expected an always open POI without periods to be open")
+	}
+	if openTypes.TemporarilyClosed.IsOpenAt(time.Now()) {
+		t.Error("expected a temporarily closed POI to be closed despite its periods")
+	}
+
+	var resp SearchboxReverseResponse
+	err = json.Unmarshal([]byte(`{"type":"FeatureCollection","features":[{"type":"Feature","properties":{
+		"name":"Palomar Airport",
+		"metadata":{"phone":"+1 760-555-0100","primary_photo":"https://example.com/a.jpg","rating":"4.5"}
+	}}]}`), &resp)
+	if err != nil {
+		t.Fatalf("expected a mistyped metadata field not to fail the response, got %v", err)
+	}
+
+	metadata = resp.Features[0].Properties.Metadata
+	if metadata.Phone != "+1 760-555-0100" || metadata.PrimaryPhoto != nil || metadata.Rating != 0 {
+		t.Errorf("expected only the well typed fields to be decoded, got %+v", metadata)
+	}
+	if metadata.Raw["primary_photo"] != "https://example.com/a.jpg" {
+		t.Errorf("expected the mistyped field to be kept in Raw, got %v", metadata.Raw["primary_photo"])
+	}
+}
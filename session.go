@@ -0,0 +1,98 @@
+package mapbox
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// Mapbox ends a session 60 minutes after its first request
+	DefaultSessionTTL = 60 * time.Minute
+)
+
+// SessionTokenManager hands out session tokens for interactive search flows, where a
+// series of suggest requests followed by a retrieve request is billed as one session.
+// Sessions are keyed by a caller defined key, e.g. a user or a form instance, so a single
+// manager can be shared by a server handling many users.
+type SessionTokenManager struct {
+	ttl      time.Duration
+	now      func() time.Time
+	mutex    sync.Mutex
+	sessions map[string]session
+	pruneAt  time.Time // expired sessions of other keys are dropped once per ttl
+}
+
+type session struct {
+	token   string
+	expires time.Time
+}
+
+// NewSessionTokenManager creates a manager whose tokens expire after ttl, which defaults to DefaultSessionTTL.
+func NewSessionTokenManager(ttl time.Duration) *SessionTokenManager {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+
+	return &SessionTokenManager{
+		ttl:      ttl,
+		now:      time.Now,
+		sessions: make(map[string]session),
+	}
+}
+
+// Token returns the session token for key, starting a new session if there is none or
+// the previous one expired.
+func (m *SessionTokenManager) Token(key string) (string, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := m.now()
+	if !now.Before(m.pruneAt) {
+		m.prune(now)
+		m.pruneAt = now.Add(m.ttl)
+	}
+
+	if s, ok := m.sessions[key]; ok && now.Before(s.expires) {
+		return s.token, nil
+	}
+
+	token, err := NewSessionToken()
+	if err != nil {
+		return "", err
+	}
+
+	m.sessions[key] = session{token: token, expires: now.Add(m.ttl)}
+	return token, nil
+}
+
+// End finishes the session for key, which should be done once a suggestion is retrieved.
+// The next call to Token starts a new session.
+func (m *SessionTokenManager) End(key string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.sessions, key)
+}
+
+func (m *SessionTokenManager) prune(now time.Time) {
+	for key, s := range m.sessions {
+		if !now.Before(s.expires) {
+			delete(m.sessions, key)
+		}
+	}
+}
+
+// NewSessionToken generates a random UUIDv4 session token.
+func NewSessionToken() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate session token. %w", err)
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
@@ -0,0 +1,166 @@
+package mapbox
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	DefaultTypeaheadDebounce = 250 * time.Millisecond
+)
+
+// TypeaheadFunc searches for a partial query, e.g. GeocodeTypeahead or SearchboxSuggestTypeahead.
+type TypeaheadFunc func(ctx context.Context, query string) (interface{}, error)
+
+// TypeaheadResult is the outcome of the search for Query. Response is whatever the
+// TypeaheadFunc returned, e.g. a *GeocodeResponse.
+type TypeaheadResult struct {
+	Query    string
+	Response interface{}
+	Err      error
+}
+
+// Typeahead turns a stream of keystrokes into searches. A search only starts once the
+// query has not changed for Debounce, a new query cancels the search in flight, and only
+// the result for the latest query is delivered.
+type Typeahead struct {
+	Search    TypeaheadFunc
+	Debounce  time.Duration
+	MinLength int // in characters, shorter queries cancel the search in flight without starting a new one
+}
+
+// NewTypeahead instantiates a Typeahead, debounce defaults to DefaultTypeaheadDebounce.
+func NewTypeahead(search TypeaheadFunc, debounce time.Duration) *Typeahead {
+	if debounce <= 0 {
+		debounce = DefaultTypeaheadDebounce
+	}
+
+	return &Typeahead{Search: search, Debounce: debounce}
+}
+
+// GeocodeTypeahead searches with ForwardGeocode in autocomplete mode. Every field of
+// template other than SearchText is used as is.
+func GeocodeTypeahead(client *Client, template ForwardGeocodeRequest) TypeaheadFunc {
+	return func(ctx context.Context, query string) (interface{}, error) {
+		req := template
+		req.SearchText = query
+		req.Autocomplete = true
+		return client.ForwardGeocode(ctx, &req)
+	}
+}
+
+// SearchboxSuggestTypeahead searches with SearchboxSuggest. Every field of template other
+// than SearchText is used as is, so template.SessionToken must be set for the whole session.
+func SearchboxSuggestTypeahead(client *Client, template SearchboxSuggestRequest) TypeaheadFunc {
+	return func(ctx context.Context, query string) (interface{}, error) {
+		req := template
+		req.SearchText = query
+		return client.SearchboxSuggest(ctx, &req)
+	}
+}
+
+type typeaheadResponse struct {
+	seq    int
+	result TypeaheadResult
+}
+
+// Run consumes queries until the channel is closed or ctx is done. The returned channel is
+// closed once the result for the last query was delivered.
+func (t *Typeahead) Run(ctx context.Context, queries <-chan string) <-chan TypeaheadResult {
+	out := make(chan TypeaheadResult)
+	go t.run(ctx, queries, out)
+	return out
+}
+
+func (t *Typeahead) run(ctx context.Context, queries <-chan string, out chan<- TypeaheadResult) {
+	defer close(out)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	var (
+		seq       int    // sequence number of the latest query
+		pending   string // latest query, waiting for the debounce
+		timer     *time.Timer
+		debounced <-chan time.Time
+		searching bool
+		cancel    context.CancelFunc = func() {}
+
+		responses = make(chan typeaheadResponse)
+		ready     TypeaheadResult
+		send      chan<- TypeaheadResult // only set while ready holds a result
+	)
+	defer func() {
+		cancel()
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		if queries == nil && debounced == nil && !searching && send == nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case query, ok := <-queries:
+			if !ok {
+				queries = nil
+				continue
+			}
+
+			// anything for an older query is stale now
+			seq++
+			cancel()
+			searching, send = false, nil
+			if timer != nil {
+				timer.Stop()
+				debounced = nil
+			}
+
+			if trimmed := strings.TrimSpace(query); trimmed == "" || utf8.RuneCountInString(trimmed) < t.MinLength {
+				continue
+			}
+
+			pending = query
+			timer = time.NewTimer(t.Debounce)
+			debounced = timer.C
+
+		case <-debounced:
+			debounced = nil
+			searching = true
+			cancel = t.search(ctx, seq, pending, responses, done)
+
+		case resp := <-responses:
+			if resp.seq != seq {
+				continue
+			}
+			searching = false
+			ready, send = resp.result, out
+
+		case send <- ready:
+			send = nil
+		}
+	}
+}
+
+// search runs the search for query in the background, the result is sent to responses
+// unless run already returned.
+func (t *Typeahead) search(ctx context.Context, seq int, query string, responses chan<- typeaheadResponse, done <-chan struct{}) context.CancelFunc {
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		resp, err := t.Search(ctx, query)
+		select {
+		case responses <- typeaheadResponse{seq: seq, result: TypeaheadResult{Query: query, Response: resp, Err: err}}:
+		case <-done:
+		}
+	}()
+
+	return cancel
+}
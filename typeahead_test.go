@@ -0,0 +1,109 @@
+package mapbox
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTypeaheadDebounce(t *testing.T) {
+	var mutex sync.Mutex
+	var searched []string
+	typeahead := NewTypeahead(func(ctx context.Context, query string) (interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		searched = append(searched, query)
+		return query + " result", nil
+	}, 20*time.Millisecond)
+
+	queries := make(chan string)
+	results := typeahead.Run(context.Background(), queries)
+
+	for _, query := range []string{"h", "he", "hel", "hell"} {
+		queries <- query
+	}
+	close(queries)
+
+	var delivered []TypeaheadResult
+	for result := range results {
+		delivered = append(delivered, result)
+	}
+
+	if len(searched) != 1 || searched[0] != "hell" {
+		t.Errorf("expected a single search for the last query, got %v", searched)
+	}
+	if len(delivered) != 1 || delivered[0].Query != "hell" || delivered[0].Response != "hell result" {
+		t.Errorf("expected only the last result, got %+v", delivered)
+	}
+}
+
+func TestTypeaheadCancelsSupersededSearch(t *testing.T) {
+	canceled := make(chan string, 1)
+	typeahead := NewTypeahead(func(ctx context.Context, query string) (interface{}, error) {
+		if query == "slow" {
+			<-ctx.Done()
+			canceled <- query
+			return nil, ctx.Err()
+		}
+		return query, nil
+	}, time.Millisecond)
+
+	queries := make(chan string)
+	results := typeahead.Run(context.Background(), queries)
+
+	queries <- "slow"
+	time.Sleep(20 * time.Millisecond) // let the search start
+	queries <- "fast"
+	close(queries)
+
+	var delivered []TypeaheadResult
+	for result := range results {
+		delivered = append(delivered, result)
+	}
+
+	select {
+	case query := <-canceled:
+		if query != "slow" {
+			t.Errorf("expected the slow search to be canceled, got %v", query)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the superseded search to be canceled")
+	}
+
+	if len(delivered) != 1 || delivered[0].Query != "fast" || delivered[0].Err != nil {
+		t.Errorf("expected only the fast result, got %+v", delivered)
+	}
+}
+
+func TestTypeaheadMinLengthCountsCharacters(t *testing.T) {
+	var mutex sync.Mutex
+	var searched []string
+	typeahead := NewTypeahead(func(ctx context.Context, query string) (interface{}, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		searched = append(searched, query)
+		return query, nil
+	}, time.Millisecond)
+	typeahead.MinLength = 3
+
+	queries := make(chan string)
+	results := typeahead.Run(context.Background(), queries)
+
+	queries <- "éé" // 4 bytes but only 2 characters
+	time.Sleep(20 * time.Millisecond)
+	queries <- "日本語"
+	close(queries)
+
+	var delivered []TypeaheadResult
+	for result := range results {
+		delivered = append(delivered, result)
+	}
+
+	if len(searched) != 1 || searched[0] != "日本語" {
+		t.Errorf("expected only the 3 character query to be searched, got %v", searched)
+	}
+	if len(delivered) != 1 || delivered[0].Query != "日本語" {
+		t.Errorf("expected only the 3 character result, got %+v", delivered)
+	}
+}
@@ -18,6 +18,12 @@ const (
 	AnnotationSpeed      = Annotation("speed")
 	AnnotationCongestion = Annotation("congestion")
 
+	// Directions only
+	AnnotationCongestionNumeric = Annotation("congestion_numeric")
+	AnnotationMaxspeed          = Annotation("maxspeed")
+	AnnotationClosure           = Annotation("closure")         // reported in RouteLeg.Closures
+	AnnotationStateOfCharge     = Annotation("state_of_charge") // electric vehicles only
+
 	ApproachUnrestricted = Approach("unrestricted")
 	ApproachCurb         = Approach("curb")
 
@@ -34,6 +40,8 @@ const (
 	TypeSecondaryAddress = Type("secondary_address")
 	TypePOI              = Type("poi")
 	TypePOILandmark      = Type("poi.landmark")
+	TypeCategory         = Type("category")
+	TypeBrand            = Type("brand")
 
 	ExcludeMotorway      = Exclude("motorway")
 	ExcludeToll          = Exclude("toll")
@@ -265,6 +273,152 @@ func (w WaypointTargets) query() string {
 
 //////////////////////////////////////////////////////////////////
 
+// Bearings restricts the direction of travel at each coordinate, a nil entry skips the coordinate.
+type Bearings []*Bearing
+
+// Bearing is the direction of travel in degrees clockwise from true north (0-360), and the
+// number of degrees it may deviate either way (0-180).
+type Bearing struct {
+	Angle int
+	Range int
+}
+
+func NewBearing(angle, deviation int) *Bearing {
+	return &Bearing{Angle: angle, Range: deviation}
+}
+
+func (b Bearings) strings() []string {
+	res := make([]string, 0, len(b))
+
+	for _, val := range b {
+		if val == nil {
+			res = append(res, "")
+			continue
+		}
+		res = append(res, fmt.Sprintf("%v,%v", val.Angle, val.Range))
+	}
+
+	return res
+}
+
+func (b Bearings) query() string {
+	return strings.Join(b.strings(), ";")
+}
+
+func (b Bearings) validate(coordinates int) error {
+	if len(b) != coordinates {
+		return fmt.Errorf("expected %v bearings, one per coordinate, got %v", coordinates, len(b))
+	}
+
+	for i, val := range b {
+		if val == nil {
+			continue
+		}
+		if val.Angle < 0 || val.Angle > 360 {
+			return fmt.Errorf("bearing %v: angle %v out of range 0-360", i, val.Angle)
+		}
+		if val.Range < 0 || val.Range > 180 {
+			return fmt.Errorf("bearing %v: range %v out of range 0-180", i, val.Range)
+		}
+	}
+
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////
+
+const (
+	RadiusDefault   = Radius(0) // skips the coordinate, the API default applies
+	RadiusUnlimited = Radius(-1)
+)
+
+// Radiuses is the maximum distance in meters each coordinate may be snapped to the road network.
+type Radiuses []Radius
+type Radius float64
+
+func (r Radiuses) strings() []string {
+	res := make([]string, 0, len(r))
+
+	for _, val := range r {
+		switch val {
+		case RadiusDefault:
+			res = append(res, "")
+		case RadiusUnlimited:
+			res = append(res, "unlimited")
+		default:
+			res = append(res, strconv.FormatFloat(float64(val), 'f', -1, 64))
+		}
+	}
+
+	return res
+}
+
+func (r Radiuses) query() string {
+	return strings.Join(r.strings(), ";")
+}
+
+func (r Radiuses) validate(coordinates int) error {
+	if len(r) != coordinates {
+		return fmt.Errorf("expected %v radiuses, one per coordinate, got %v", coordinates, len(r))
+	}
+
+	for i, val := range r {
+		if val < 0 && val != RadiusUnlimited {
+			return fmt.Errorf("radius %v: %v must be positive or RadiusUnlimited", i, val)
+		}
+	}
+
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////
+
+// Layers picks the road layer of each coordinate, e.g. the lower deck of a bridge or the
+// carriageway under an overpass. A nil entry skips the coordinate.
+type Layers []*Layer
+
+// Layer is the z-order of a road, 0 is ground level, range -128 to 128.
+type Layer int
+
+func NewLayer(layer int) *Layer {
+	l := Layer(layer)
+	return &l
+}
+
+func (l Layers) strings() []string {
+	res := make([]string, 0, len(l))
+
+	for _, val := range l {
+		if val == nil {
+			res = append(res, "")
+			continue
+		}
+		res = append(res, strconv.Itoa(int(*val)))
+	}
+
+	return res
+}
+
+func (l Layers) query() string {
+	return strings.Join(l.strings(), ";")
+}
+
+func (l Layers) validate(coordinates int) error {
+	if len(l) != coordinates {
+		return fmt.Errorf("expected %v layers, one per coordinate, got %v", coordinates, len(l))
+	}
+
+	for i, val := range l {
+		if val != nil && (*val < -128 || *val > 128) {
+			return fmt.Errorf("layer %v: %v out of range -128 to 128", i, *val)
+		}
+	}
+
+	return nil
+}
+
+//////////////////////////////////////////////////////////////////
+
 type DepartAt time.Time
 
 const (